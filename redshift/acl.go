@@ -0,0 +1,164 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+
+// A decoded aclitem such as `group "sales team"=r*w/etl`: the grantee, the
+// privileges it holds, those it may grant on (marked with *) and who granted them.
+type redshiftAclItem struct {
+	granteeType  string
+	grantee      string
+	privileges   []string
+	grantOptions []string
+	grantor      string
+}
+
+func (a redshiftAclItem) matches(grantee redshiftGrantee) bool {
+	return a.granteeType == grantee.granteeType && a.grantee == grantee.name
+}
+
+func parseRedshiftAcl(acl []string) ([]redshiftAclItem, error) {
+	var items []redshiftAclItem
+	for _, item := range acl {
+		aclItem, parseErr := parseRedshiftAclItem(item)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		items = append(items, aclItem)
+	}
+	return items, nil
+}
+
+func parseRedshiftAclItem(item string) (redshiftAclItem, error) {
+	aclItem := redshiftAclItem {
+		granteeType: "user",
+	}
+
+	rest := item
+	for _, granteeType := range []string{"group", "role"} {
+		if strings.HasPrefix(rest, granteeType + " ") {
+			aclItem.granteeType = granteeType
+			rest = rest[len(granteeType) + 1:]
+			break
+		}
+	}
+
+	grantee, rest, granteeErr := parseRedshiftAclIdentifier(rest)
+	if granteeErr != nil {
+		return aclItem, fmt.Errorf("Invalid aclitem %s: %s", item, granteeErr)
+	}
+	if grantee == "" {
+		if aclItem.granteeType != "user" {
+			return aclItem, fmt.Errorf("Invalid aclitem %s: missing %s name", item, aclItem.granteeType)
+		}
+		aclItem.granteeType = "public"
+	}
+	aclItem.grantee = grantee
+
+	if !strings.HasPrefix(rest, "=") {
+		return aclItem, fmt.Errorf("Invalid aclitem %s: expected =", item)
+	}
+	rest = rest[1:]
+
+	aclItem.privileges = []string{}
+	aclItem.grantOptions = []string{}
+	for rest != "" && rest[0] != '/' {
+		code := rest[0]
+		rest = rest[1:]
+		grantOption := strings.HasPrefix(rest, "*")
+		if grantOption {
+			rest = rest[1:]
+		}
+
+		// rule and trigger are legacy postgres privileges that cannot be granted,
+		// anything else unknown is left for the resources to ignore as well
+		privilege, ok := redshiftAclPrivilegeCodes[code]
+		if !ok {
+			if code != 'R' && code != 't' {
+				log.Println("info | parseRedshiftAclItem | skipping unknown privilege |", string(code), "|", item)
+			}
+			continue
+		}
+		aclItem.privileges = append(aclItem.privileges, privilege)
+		if grantOption {
+			aclItem.grantOptions = append(aclItem.grantOptions, privilege)
+		}
+	}
+
+	if !strings.HasPrefix(rest, "/") {
+		return aclItem, fmt.Errorf("Invalid aclitem %s: expected /", item)
+	}
+
+	grantor, rest, grantorErr := parseRedshiftAclIdentifier(rest[1:])
+	if grantorErr != nil {
+		return aclItem, fmt.Errorf("Invalid aclitem %s: %s", item, grantorErr)
+	}
+	if rest != "" {
+		return aclItem, fmt.Errorf("Invalid aclitem %s: unexpected %s", item, rest)
+	}
+	aclItem.grantor = grantor
+
+	return aclItem, nil
+}
+
+// Reads a name up to the next = or /, unquoting it when it is double quoted
+// ("" standing for a literal quote inside).
+func parseRedshiftAclIdentifier(s string) (string, string, error) {
+	if !strings.HasPrefix(s, "\"") {
+		end := strings.IndexAny(s, "=/")
+		if end < 0 {
+			end = len(s)
+		}
+		return s[:end], s[end:], nil
+	}
+
+	var name strings.Builder
+	for i := 1; i < len(s); i++ {
+		if s[i] != '"' {
+			name.WriteByte(s[i])
+			continue
+		}
+		if i + 1 < len(s) && s[i + 1] == '"' {
+			name.WriteByte('"')
+			i++
+			continue
+		}
+		return name.String(), s[i + 1:], nil
+	}
+	return "", "", fmt.Errorf("unterminated quoted name")
+}
+
+// Runs a query returning (key, acl) rows and keeps the keys whose ACL holds any
+// privilege for the grantee.
+func redshiftCollectGranted(tx *sql.Tx, query string, grantee redshiftGrantee) ([]string, error) {
+	rows, selectErr := tx.Query(query)
+	if selectErr != nil {
+		return nil, selectErr
+	}
+
+	defer rows.Close()
+	var keys []string
+	for rows.Next() {
+		var key string
+		var acl pq.StringArray
+		if selectRowErr := rows.Scan(&key, &acl); selectRowErr != nil {
+			return nil, selectRowErr
+		}
+		privileges, parseErr := redshiftAclGranteePrivileges(acl, grantee)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		if len(privileges) > 0 {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, rows.Err()
+}
@@ -0,0 +1,183 @@
+package redshift
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRedshiftAclItem(t *testing.T) {
+	cases := []struct {
+		item     string
+		expected redshiftAclItem
+	}{
+		{
+			item: "etl=arwdRxtDPA/etl",
+			expected: redshiftAclItem {
+				granteeType:  "user",
+				grantee:      "etl",
+				privileges:   []string{"insert", "select", "update", "delete", "references", "drop", "truncate", "alter"},
+				grantOptions: []string{},
+				grantor:      "etl",
+			},
+		},
+		{
+			item: "group analysts=r/etl",
+			expected: redshiftAclItem {
+				granteeType:  "group",
+				grantee:      "analysts",
+				privileges:   []string{"select"},
+				grantOptions: []string{},
+				grantor:      "etl",
+			},
+		},
+		{
+			item: "role analyst=UC/etl",
+			expected: redshiftAclItem {
+				granteeType:  "role",
+				grantee:      "analyst",
+				privileges:   []string{"usage", "create"},
+				grantOptions: []string{},
+				grantor:      "etl",
+			},
+		},
+		{
+			item: "=r/etl",
+			expected: redshiftAclItem {
+				granteeType:  "public",
+				grantee:      "",
+				privileges:   []string{"select"},
+				grantOptions: []string{},
+				grantor:      "etl",
+			},
+		},
+		{
+			item: `group "sales team"=r*w/etl`,
+			expected: redshiftAclItem {
+				granteeType:  "group",
+				grantee:      "sales team",
+				privileges:   []string{"select", "update"},
+				grantOptions: []string{"select"},
+				grantor:      "etl",
+			},
+		},
+		{
+			item: `"say ""hi"""=X*/"etl/admin"`,
+			expected: redshiftAclItem {
+				granteeType:  "user",
+				grantee:      `say "hi"`,
+				privileges:   []string{"execute"},
+				grantOptions: []string{"execute"},
+				grantor:      "etl/admin",
+			},
+		},
+		{
+			item: `"group"=U/etl`,
+			expected: redshiftAclItem {
+				granteeType:  "user",
+				grantee:      "group",
+				privileges:   []string{"usage"},
+				grantOptions: []string{},
+				grantor:      "etl",
+			},
+		},
+		{
+			item: "etl=/etl",
+			expected: redshiftAclItem {
+				granteeType:  "user",
+				grantee:      "etl",
+				privileges:   []string{},
+				grantOptions: []string{},
+				grantor:      "etl",
+			},
+		},
+		{
+			item: "etl=rZ*T/etl",
+			expected: redshiftAclItem {
+				granteeType:  "user",
+				grantee:      "etl",
+				privileges:   []string{"select", "temporary"},
+				grantOptions: []string{},
+				grantor:      "etl",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		aclItem, parseErr := parseRedshiftAclItem(c.item)
+		if parseErr != nil {
+			t.Errorf("parseRedshiftAclItem(%q) failed: %s", c.item, parseErr)
+			continue
+		}
+		if !reflect.DeepEqual(aclItem, c.expected) {
+			t.Errorf("parseRedshiftAclItem(%q) = %+v, expected %+v", c.item, aclItem, c.expected)
+		}
+	}
+}
+
+func TestParseRedshiftAclItemInvalid(t *testing.T) {
+	cases := []string{
+		"",
+		"etl",
+		"etl=r",
+		"group =r/etl",
+		`"etl=r/etl`,
+		"etl=r/etl/etl",
+	}
+
+	for _, item := range cases {
+		if _, parseErr := parseRedshiftAclItem(item); parseErr == nil {
+			t.Errorf("parseRedshiftAclItem(%q) should have failed", item)
+		}
+	}
+}
+
+func TestParseRedshiftAcl(t *testing.T) {
+	cases := []struct {
+		acl      []string
+		expected int
+	}{
+		{acl: nil, expected: 0},
+		{acl: []string{}, expected: 0},
+		{acl: []string{"=T/etl", "etl=CT/etl"}, expected: 2},
+	}
+
+	for _, c := range cases {
+		items, parseErr := parseRedshiftAcl(c.acl)
+		if parseErr != nil {
+			t.Errorf("parseRedshiftAcl(%q) failed: %s", c.acl, parseErr)
+			continue
+		}
+		if len(items) != c.expected {
+			t.Errorf("parseRedshiftAcl(%q) returned %d items, expected %d", c.acl, len(items), c.expected)
+		}
+	}
+}
+
+func TestRedshiftAclGranteePrivileges(t *testing.T) {
+	acl := []string{"=r/etl", "group analysts=rw/etl", "analysts=a/etl", "role analysts=d/etl"}
+	cases := []struct {
+		grantee  redshiftGrantee
+		expected []string
+	}{
+		{grantee: redshiftGrantee { granteeType: "public" }, expected: []string{"select"}},
+		{grantee: redshiftGrantee { granteeType: "group", name: "analysts" }, expected: []string{"select", "update"}},
+		{grantee: redshiftGrantee { granteeType: "user", name: "analysts" }, expected: []string{"insert"}},
+		{grantee: redshiftGrantee { granteeType: "role", name: "analysts" }, expected: []string{"delete"}},
+		{grantee: redshiftGrantee { granteeType: "user", name: "etl" }, expected: []string{}},
+	}
+
+	for _, c := range cases {
+		privileges, parseErr := redshiftAclGranteePrivileges(acl, c.grantee)
+		if parseErr != nil {
+			t.Errorf("redshiftAclGranteePrivileges(%+v) failed: %s", c.grantee, parseErr)
+			continue
+		}
+		if !reflect.DeepEqual(privileges, c.expected) {
+			t.Errorf("redshiftAclGranteePrivileges(%+v) = %v, expected %v", c.grantee, privileges, c.expected)
+		}
+	}
+
+	if privileges, _ := redshiftAclGranteePrivileges(nil, redshiftGrantee { granteeType: "public" }); len(privileges) != 0 {
+		t.Errorf("redshiftAclGranteePrivileges(nil) = %v, expected none", privileges)
+	}
+}
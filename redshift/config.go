@@ -3,6 +3,9 @@ package redshift
 import (
 	"database/sql"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -44,8 +47,63 @@ type Config struct {
 }
 
 type Client struct {
-	config Config
-	db     *sql.DB
+	config               Config
+	db                   *sql.DB
+	version              redshiftVersion
+	svvPrivilegesVersion redshiftVersion
+}
+
+// The Redshift release the cluster runs, e.g. 1.0.38698 from the
+// "... Redshift 1.0.38698" suffix of SELECT version().
+type redshiftVersion struct {
+	major int
+	minor int
+	patch int
+}
+
+var redshiftVersionPattern = regexp.MustCompile(`Redshift (\d+\.\d+\.\d+)`)
+
+func parseRedshiftVersion(version string) (redshiftVersion, error) {
+	match := redshiftVersionPattern.FindStringSubmatch(version)
+	if match == nil {
+		return redshiftVersion{}, fmt.Errorf("Cannot find the Redshift version in %s", version)
+	}
+	return parseRedshiftVersionNumber(match[1])
+}
+
+func parseRedshiftVersionNumber(number string) (redshiftVersion, error) {
+	parts := strings.Split(number, ".")
+	if len(parts) != 3 {
+		return redshiftVersion{}, fmt.Errorf("Invalid Redshift version %s, expected major.minor.patch", number)
+	}
+
+	var numbers []int
+	for _, part := range parts {
+		n, atoiErr := strconv.Atoi(part)
+		if atoiErr != nil {
+			return redshiftVersion{}, fmt.Errorf("Invalid Redshift version %s, expected major.minor.patch", number)
+		}
+		numbers = append(numbers, n)
+	}
+	return redshiftVersion{numbers[0], numbers[1], numbers[2]}, nil
+}
+
+func (v redshiftVersion) atLeast(other redshiftVersion) bool {
+	if v.major != other.major {
+		return v.major > other.major
+	}
+	if v.minor != other.minor {
+		return v.minor > other.minor
+	}
+	return v.patch >= other.patch
+}
+
+// svv_schema_privileges, svv_relation_privileges, svv_function_privileges and
+// svv_default_privileges shipped together with role-based access control, from
+// the release set by svv_privileges_min_version. A cluster whose version could
+// not be read is treated as older and its catalog ACLs are decoded instead.
+func (c *Client) supportsSvvPrivileges() bool {
+	return c.version != (redshiftVersion{}) && c.version.atLeast(c.svvPrivilegesVersion)
 }
 
 func (c *Config) Client() (*Client, error) {
@@ -0,0 +1,65 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+
+func dataSourceRedshiftSchema() *schema.Resource {
+	return &schema.Resource {
+		Read: dataSourceRedshiftSchemaRead,
+		Schema: map[string]*schema.Schema {
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"owner": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"quota": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "empty unless the schema is owned by the provider's user or that user is a superuser",
+			},
+			"disk_usage_mb": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"quota_exceeded": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceRedshiftSchemaRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+
+	selectQuery := fmt.Sprintf("%s WHERE n.nspname = '%s'", redshiftSchemaSelectQuery, d.Get("name"))
+	schemaInfo, selectErr := redshiftSchemaScan(client.QueryRow(selectQuery))
+
+	if selectErr != nil {
+		log.Println("error | dataSourceRedshiftSchemaRead | selectErr |", selectErr)
+		if selectErr == sql.ErrNoRows {
+			return fmt.Errorf("Schema not found")
+		} else {
+			return selectErr
+		}
+	}
+
+	d.SetId(schemaInfo.id)
+	d.Set("owner", schemaInfo.owner)
+	if schemaInfo.quotaVisible {
+		d.Set("quota", schemaInfo.quota())
+		d.Set("disk_usage_mb", schemaInfo.diskUsage)
+		d.Set("quota_exceeded", schemaInfo.quotaExceeded())
+	}
+
+	return nil
+}
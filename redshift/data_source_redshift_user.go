@@ -0,0 +1,120 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+
+func dataSourceRedshiftUser() *schema.Resource {
+	return &schema.Resource {
+		Read: dataSourceRedshiftUserRead,
+		Schema: map[string]*schema.Schema {
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "usesysid"},
+			},
+			"usesysid": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"superuser": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"createdb": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"connection_limit": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"valid_until": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"groups": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema { Type: schema.TypeString },
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceRedshiftUserRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+
+	var selectQuery string
+	if id, ok := d.GetOk("usesysid"); ok {
+		selectQuery = fmt.Sprintf("%s WHERE u.usesysid = %s", redshiftUserSelectQuery, id)
+	} else {
+		selectQuery = fmt.Sprintf("%s WHERE u.usename = '%s'", redshiftUserSelectQuery, d.Get("name"))
+	}
+	user, selectErr := redshiftUserScan(client.QueryRow(selectQuery))
+
+	if selectErr != nil {
+		log.Println("error | dataSourceRedshiftUserRead | selectErr |", selectErr)
+		if selectErr == sql.ErrNoRows {
+			return fmt.Errorf("User not found")
+		} else {
+			return selectErr
+		}
+	}
+
+	groups, selectGroupsErr := redshiftUserGroups(client)
+	if selectGroupsErr != nil {
+		log.Println("error | dataSourceRedshiftUserRead | selectGroupsErr |", selectGroupsErr)
+		return selectGroupsErr
+	}
+
+	d.SetId(user.id)
+	d.Set("name", user.name)
+	d.Set("usesysid", user.id)
+	d.Set("superuser", user.superuser)
+	d.Set("createdb", user.createdb)
+	d.Set("connection_limit", user.connectionLimit)
+	d.Set("valid_until", user.validUntil)
+	d.Set("enabled", user.enabled())
+	d.Set("groups", groups[user.id])
+
+	return nil
+}
+
+func redshiftUserGroups(client *sql.DB) (map[string][]string, error) {
+	groups := make(map[string][]string)
+
+	rows, selectErr := client.Query(`
+		SELECT
+			u.usesysid,
+			g.groname
+		FROM pg_group g
+			JOIN pg_user u ON u.usesysid = ANY(g.grolist)
+	`)
+	if selectErr != nil {
+		return nil, selectErr
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var group string
+		if selectRowErr := rows.Scan(&id, &group); selectRowErr != nil {
+			return nil, selectRowErr
+		}
+		groups[id] = append(groups[id], group)
+	}
+
+	return groups, rows.Err()
+}
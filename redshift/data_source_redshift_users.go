@@ -0,0 +1,169 @@
+package redshift
+
+import (
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+
+func dataSourceRedshiftUsers() *schema.Resource {
+	return &schema.Resource {
+		Read: dataSourceRedshiftUsersRead,
+		Schema: map[string]*schema.Schema {
+			"name_regex": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"group": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"superuser": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"createdb": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema { Type: schema.TypeString },
+				Computed: true,
+			},
+			"users": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource {
+					Schema: map[string]*schema.Schema {
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"usesysid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"superuser": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"createdb": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"connection_limit": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"valid_until": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"groups": {
+							Type:     schema.TypeSet,
+							Elem:     &schema.Schema { Type: schema.TypeString },
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedshiftUsersRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		var compileErr error
+		if nameRegex, compileErr = regexp.Compile(v.(string)); compileErr != nil {
+			log.Println("error | dataSourceRedshiftUsersRead | compileErr |", compileErr)
+			return compileErr
+		}
+	}
+
+	groups, selectGroupsErr := redshiftUserGroups(client)
+	if selectGroupsErr != nil {
+		log.Println("error | dataSourceRedshiftUsersRead | selectGroupsErr |", selectGroupsErr)
+		return selectGroupsErr
+	}
+
+	rows, selectErr := client.Query(redshiftUserSelectQuery + " ORDER BY u.usename")
+	if selectErr != nil {
+		log.Println("error | dataSourceRedshiftUsersRead | selectErr |", selectErr)
+		return selectErr
+	}
+
+	defer rows.Close()
+	var names []string
+	var users []map[string]interface{}
+	for rows.Next() {
+		user, selectRowErr := redshiftUserScan(rows)
+		if selectRowErr != nil {
+			log.Println("error | dataSourceRedshiftUsersRead | selectRowErr |", selectRowErr)
+			return selectRowErr
+		}
+
+		if nameRegex != nil && !nameRegex.MatchString(user.name) {
+			continue
+		}
+		if group, ok := d.GetOk("group"); ok && !stringInList(group.(string), groups[user.id]) {
+			continue
+		}
+		if v, ok := d.GetOkExists("superuser"); ok && v.(bool) != user.superuser {
+			continue
+		}
+		if v, ok := d.GetOkExists("createdb"); ok && v.(bool) != user.createdb {
+			continue
+		}
+		if v, ok := d.GetOkExists("enabled"); ok && v.(bool) != user.enabled() {
+			continue
+		}
+
+		names = append(names, user.name)
+		users = append(users, map[string]interface{} {
+			"name":             user.name,
+			"usesysid":         user.id,
+			"superuser":        user.superuser,
+			"createdb":         user.createdb,
+			"connection_limit": user.connectionLimit,
+			"valid_until":      user.validUntil,
+			"enabled":          user.enabled(),
+			"groups":           groups[user.id],
+		})
+	}
+
+	if rowsErr := rows.Err(); rowsErr != nil {
+		log.Println("error | dataSourceRedshiftUsersRead | rowsErr |", rowsErr)
+		return rowsErr
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join(names, ","))))
+	d.Set("names", names)
+	d.Set("users", users)
+
+	return nil
+}
+
+func stringInList(s string, list []string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
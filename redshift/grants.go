@@ -0,0 +1,872 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+type redshiftGrantObjectType struct {
+	keyword        string
+	allKeyword     string
+	requiresSchema bool
+	privileges     []string
+}
+
+var redshiftGrantObjectTypes = map[string]redshiftGrantObjectType {
+	"database": {
+		keyword:    "DATABASE",
+		privileges: []string{"create", "temporary"},
+	},
+	"schema": {
+		keyword:        "SCHEMA",
+		requiresSchema: true,
+		privileges:     []string{"create", "usage"},
+	},
+	"table": {
+		keyword:        "TABLE",
+		allKeyword:     "ALL TABLES IN SCHEMA",
+		requiresSchema: true,
+		privileges:     []string{"select", "insert", "update", "delete", "references", "drop", "alter", "truncate"},
+	},
+	"view": {
+		keyword:        "TABLE",
+		allKeyword:     "ALL TABLES IN SCHEMA",
+		requiresSchema: true,
+		privileges:     []string{"select", "references", "drop", "alter"},
+	},
+	"function": {
+		keyword:        "FUNCTION",
+		allKeyword:     "ALL FUNCTIONS IN SCHEMA",
+		requiresSchema: true,
+		privileges:     []string{"execute"},
+	},
+	"procedure": {
+		keyword:        "PROCEDURE",
+		allKeyword:     "ALL PROCEDURES IN SCHEMA",
+		requiresSchema: true,
+		privileges:     []string{"execute"},
+	},
+	"language": {
+		keyword:    "LANGUAGE",
+		privileges: []string{"usage"},
+	},
+	"datashare": {
+		keyword:    "DATASHARE",
+		privileges: []string{"alter", "share"},
+	},
+}
+
+type redshiftDefaultAclObjectType struct {
+	keyword    string
+	aclCode    string
+	svvType    string
+	privileges []string
+}
+
+var redshiftDefaultAclObjectTypes = map[string]redshiftDefaultAclObjectType {
+	"tables": {
+		keyword:    "TABLES",
+		aclCode:    "r",
+		svvType:    "RELATION",
+		privileges: []string{"select", "insert", "update", "delete", "references", "drop", "alter", "truncate"},
+	},
+	"functions": {
+		keyword:    "FUNCTIONS",
+		aclCode:    "f",
+		svvType:    "FUNCTION",
+		privileges: []string{"execute"},
+	},
+	"procedures": {
+		keyword:    "PROCEDURES",
+		aclCode:    "p",
+		svvType:    "PROCEDURE",
+		privileges: []string{"execute"},
+	},
+}
+
+// pg_proc_info.prokind of each kind of function.
+var redshiftProkinds = map[string]string {
+	"function":  "f",
+	"procedure": "p",
+}
+
+var redshiftAclPrivilegeCodes = map[byte]string {
+	'r': "select",
+	'a': "insert",
+	'w': "update",
+	'd': "delete",
+	'x': "references",
+	'D': "drop",
+	'A': "alter",
+	'P': "truncate",
+	'U': "usage",
+	'C': "create",
+	'T': "temporary",
+	'X': "execute",
+}
+
+// Argument types as oidvectortypes() spells them, keyed by the aliases Redshift
+// accepts in function signatures.
+var redshiftFunctionArgumentTypes = map[string]string {
+	"int":         "integer",
+	"int4":        "integer",
+	"int2":        "smallint",
+	"int8":        "bigint",
+	"float":       "double precision",
+	"float8":      "double precision",
+	"float4":      "real",
+	"decimal":     "numeric",
+	"bool":        "boolean",
+	"char":        "character",
+	"bpchar":      "character",
+	"nchar":       "character",
+	"varchar":     "character varying",
+	"nvarchar":    "character varying",
+	"timestamp":   "timestamp without time zone",
+	"timestamptz": "timestamp with time zone",
+	"time":        "time without time zone",
+	"timetz":      "time with time zone",
+}
+
+type redshiftGrantee struct {
+	granteeType string
+	name        string
+}
+
+func (g redshiftGrantee) sql() string {
+	switch g.granteeType {
+	case "group":
+		return "GROUP " + g.name
+	case "role":
+		return "ROLE " + g.name
+	case "public":
+		return "PUBLIC"
+	}
+	return g.name
+}
+
+// A set of objects of a single type to grant on. Without objects, table, view,
+// function and procedure grants cover everything of that type in the schema.
+type redshiftGrantTarget struct {
+	objectType string
+	database   string
+	schema     string
+	objects    []string
+}
+
+func (t redshiftGrantTarget) onClause() string {
+	objectType := redshiftGrantObjectTypes[t.objectType]
+
+	switch t.objectType {
+	case "database":
+		return fmt.Sprintf("DATABASE %s", t.database)
+	case "schema":
+		return fmt.Sprintf("SCHEMA %s", t.schema)
+	}
+
+	if len(t.objects) == 0 {
+		return fmt.Sprintf("%s %s", objectType.allKeyword, t.schema)
+	}
+
+	var objects []string
+	for _, object := range t.objects {
+		if t.objectType == "function" || t.objectType == "procedure" {
+			object = redshiftQuoteFunctionName(object)
+		}
+		if objectType.requiresSchema {
+			object = fmt.Sprintf("%s.%s", t.schema, object)
+		}
+		objects = append(objects, object)
+	}
+	return fmt.Sprintf("%s %s", objectType.keyword, strings.Join(objects, ", "))
+}
+
+var redshiftPlainIdentifier = regexp.MustCompile(`^[a-z_][a-z0-9_$]*$`)
+
+// Quotes the name of a normalized signature again when it would not survive
+// Redshift folding it to lowercase, e.g. F_Add(integer) becomes "F_Add"(integer).
+func redshiftQuoteFunctionName(signature string) string {
+	open := strings.LastIndex(signature, "(")
+	if open < 0 {
+		return signature
+	}
+	name := signature[:open]
+	if redshiftPlainIdentifier.MatchString(name) {
+		return signature
+	}
+	return fmt.Sprintf(`"%s"%s`, strings.ReplaceAll(name, `"`, `""`), signature[open:])
+}
+
+// Rewrites a function or procedure signature the way Redshift identifies
+// overloads, e.g. "F_Add(INT, int4)" becomes "f_add(integer, integer)". Quoted
+// names keep their case but lose their quotes, as pg_proc spells them.
+func redshiftNormalizeFunctionSignature(signature string) (string, error) {
+	invalid := fmt.Errorf("Function %s must be a signature such as f_add(integer, integer)", signature)
+	signature = strings.TrimSpace(signature)
+
+	var name string
+	var open int
+	if strings.HasPrefix(signature, "\"") {
+		unquoted, rest, nameErr := parseRedshiftAclIdentifier(signature)
+		if nameErr != nil || !strings.HasPrefix(strings.TrimSpace(rest), "(") {
+			return "", invalid
+		}
+		name = unquoted
+		open = len(signature) - len(strings.TrimSpace(rest))
+	} else {
+		open = strings.Index(signature, "(")
+		if open < 0 {
+			return "", invalid
+		}
+		name = strings.ToLower(strings.TrimSpace(signature[:open]))
+	}
+	if name == "" || !strings.HasSuffix(signature, ")") {
+		return "", invalid
+	}
+
+	// split the arguments on top level commas only, numeric(10,2) is a single type
+	var arguments []string
+	var argument strings.Builder
+	depth := 0
+	body := strings.TrimSuffix(signature[open + 1:], ")")
+	for _, c := range body {
+		switch {
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			arguments = append(arguments, argument.String())
+			argument.Reset()
+			continue
+		}
+		if depth == 0 && c != ')' {
+			argument.WriteRune(c)
+		}
+	}
+	arguments = append(arguments, argument.String())
+
+	var types []string
+	for _, argument := range arguments {
+		argumentType := strings.Join(strings.Fields(strings.ToLower(argument)), " ")
+		if argumentType == "" {
+			continue
+		}
+		if alias, ok := redshiftFunctionArgumentTypes[argumentType]; ok {
+			argumentType = alias
+		}
+		types = append(types, argumentType)
+	}
+
+	return fmt.Sprintf("%s(%s)", name, strings.Join(types, ", ")), nil
+}
+
+func redshiftGrantStatement(privileges []string, target redshiftGrantTarget, grantee redshiftGrantee) string {
+	return fmt.Sprintf("GRANT %s ON %s TO %s", strings.ToUpper(strings.Join(privileges, ",")), target.onClause(), grantee.sql())
+}
+
+func redshiftRevokeStatement(privileges []string, target redshiftGrantTarget, grantee redshiftGrantee, cascade bool) string {
+	statement := fmt.Sprintf("REVOKE %s ON %s FROM %s", strings.ToUpper(strings.Join(privileges, ",")), target.onClause(), grantee.sql())
+	if cascade {
+		statement += " CASCADE"
+	}
+	return statement
+}
+
+// The grantee a grant resource names in the attribute called after its type,
+// e.g. group = "analysts".
+func redshiftResourceGrantee(d *schema.ResourceData, granteeType string) redshiftGrantee {
+	return redshiftGrantee {
+		granteeType: granteeType,
+		name:        d.Get(granteeType).(string),
+	}
+}
+
+// Builds the ID of a grant resource out of the IDs of the named objects, e.g.
+// grosysid-nspoid for a group and a schema.
+func redshiftGrantResourceId(client interface{ QueryRow(string, ...interface{}) *sql.Row }, kinds []string, names []string) (string, error) {
+	var ids []string
+	for i, kind := range kinds {
+		var id string
+		selectQuery := fmt.Sprintf(redshiftImportLookupQueries[kind], names[i])
+		if selectErr := client.QueryRow(selectQuery).Scan(&id); selectErr != nil {
+			if selectErr == sql.ErrNoRows {
+				return "", fmt.Errorf("Cannot find %s %s", kind, names[i])
+			}
+			return "", selectErr
+		}
+		ids = append(ids, id)
+	}
+	return strings.Join(ids, "-"), nil
+}
+
+// Resolves the IDs a grant resource is keyed by back to names. Returns no names
+// when any of the objects no longer exists.
+func redshiftGrantResourceNames(client *sql.DB, kinds []string, ids []string) ([]string, error) {
+	if len(ids) != len(kinds) {
+		return nil, fmt.Errorf("Invalid ID %s, expected %s", strings.Join(ids, "-"), strings.Join(kinds, "-"))
+	}
+
+	var names []string
+	for i, kind := range kinds {
+		var name string
+		selectQuery := fmt.Sprintf(redshiftGrantNameQueries[kind], ids[i])
+		if selectErr := client.QueryRow(selectQuery).Scan(&name); selectErr != nil {
+			if selectErr == sql.ErrNoRows {
+				return nil, nil
+			}
+			return nil, selectErr
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// Splits the boolean privilege attributes that changed into the privileges to
+// grant and the privileges to revoke.
+func redshiftChangedPrivileges(d *schema.ResourceData, privileges []string) ([]string, []string) {
+	var grants []string
+	var revokes []string
+	for _, privilege := range privileges {
+		if !d.HasChange(privilege) {
+			continue
+		}
+		if d.Get(privilege).(bool) {
+			grants = append(grants, strings.ToUpper(privilege))
+		} else {
+			revokes = append(revokes, strings.ToUpper(privilege))
+		}
+	}
+	return grants, revokes
+}
+
+// Splits the changes to a set of privileges into the privileges to grant and
+// the privileges to revoke.
+func redshiftChangedPrivilegesSet(d *schema.ResourceData, key string) ([]string, []string) {
+	o, n := d.GetChange(key)
+	grants := usersSetToList(n.(*schema.Set).Difference(o.(*schema.Set)))
+	revokes := usersSetToList(o.(*schema.Set).Difference(n.(*schema.Set)))
+	sort.Strings(grants)
+	sort.Strings(revokes)
+	return grants, revokes
+}
+
+// The ALTER DEFAULT PRIVILEGES statement granting or revoking privileges on
+// objects the owner creates later, in one schema or (without one) anywhere.
+func redshiftDefaultPrivilegesStatement(action string, privileges []string, owner string, schema string, objectType string, grantee redshiftGrantee) string {
+	scope := fmt.Sprintf("FOR USER %s", owner)
+	if schema != "" {
+		scope = fmt.Sprintf("%s IN SCHEMA %s", scope, schema)
+	}
+
+	direction := "TO"
+	if action == "REVOKE" {
+		direction = "FROM"
+	}
+
+	return fmt.Sprintf("ALTER DEFAULT PRIVILEGES %s %s %s ON %s %s %s", scope, action, strings.ToUpper(strings.Join(privileges, ",")), redshiftDefaultAclObjectTypes[objectType].keyword, direction, grantee.sql())
+}
+
+func redshiftValidatePrivileges(objectType string, privileges []string) error {
+	allowed := redshiftGrantObjectTypes[objectType].privileges
+	for _, privilege := range privileges {
+		if !stringInList(privilege, allowed) {
+			return fmt.Errorf("Privilege %s is not valid for %s, expected one of %s", privilege, objectType, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+// Reads the privileges the grantee holds on every object covered by the target,
+// keyed by object name (functions and procedures by their signature).
+func redshiftReadGrantedPrivileges(client *Client, target redshiftGrantTarget, grantee redshiftGrantee) (map[string][]string, error) {
+	switch target.objectType {
+	case "datashare":
+		return redshiftReadSvvPrivileges(client.db, target, grantee)
+	case "schema", "table", "view", "function", "procedure":
+		if client.supportsSvvPrivileges() {
+			return redshiftReadSvvPrivileges(client.db, target, grantee)
+		}
+	}
+
+	var selectQuery string
+	switch target.objectType {
+	case "database":
+		return redshiftReadDatabasePrivileges(client.db, target, grantee)
+	case "schema":
+		selectQuery = fmt.Sprintf("SELECT nspname, nspacl FROM pg_namespace WHERE nspname = '%s'", target.schema)
+	case "table", "view":
+		selectQuery = fmt.Sprintf(`
+			SELECT
+				c.relname,
+				c.relacl
+			FROM pg_class c
+				JOIN pg_namespace n ON n.oid = c.relnamespace
+			WHERE c.relkind IN ('r', 'v')
+				AND n.nspname = '%s'
+				%s
+		`, target.schema, redshiftObjectFilter("c.relname", target.objects))
+	case "function", "procedure":
+		selectQuery = fmt.Sprintf(`
+			SELECT
+				p.proname + '(' + oidvectortypes(p.proargtypes) + ')',
+				p.proacl
+			FROM pg_proc_info p
+				JOIN pg_namespace n ON n.oid = p.pronamespace
+			WHERE p.prokind = '%s'
+				AND n.nspname = '%s'
+				%s
+		`, redshiftProkinds[target.objectType], target.schema, redshiftObjectFilter("p.proname + '(' + oidvectortypes(p.proargtypes) + ')'", target.objects))
+	case "language":
+		selectQuery = fmt.Sprintf(`
+			SELECT
+				lanname,
+				lanacl
+			FROM pg_language
+			WHERE true
+				%s
+		`, redshiftObjectFilter("lanname", target.objects))
+	default:
+		return nil, fmt.Errorf("Unsupported object type %s", target.objectType)
+	}
+
+	rows, selectErr := client.db.Query(selectQuery)
+	if selectErr != nil {
+		return nil, selectErr
+	}
+
+	defer rows.Close()
+	privileges := make(map[string][]string)
+	for rows.Next() {
+		var object string
+		var acl pq.StringArray
+		if selectRowErr := rows.Scan(&object, &acl); selectRowErr != nil {
+			return nil, selectRowErr
+		}
+		objectPrivileges, parseErr := redshiftAclGranteePrivileges(acl, grantee)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		privileges[object] = objectPrivileges
+	}
+
+	// named objects that are missing hold no privileges at all
+	for _, object := range target.objects {
+		if _, ok := privileges[object]; !ok {
+			privileges[object] = []string{}
+		}
+	}
+
+	return privileges, rows.Err()
+}
+
+// A NULL datacl stands for the default ACL, under which PUBLIC may create
+// temporary tables and the owner holds every privilege.
+func redshiftReadDatabasePrivileges(client *sql.DB, target redshiftGrantTarget, grantee redshiftGrantee) (map[string][]string, error) {
+	var database string
+	var acl pq.StringArray
+	var owner sql.NullString
+	selectQuery := fmt.Sprintf(`
+		SELECT
+			d.datname,
+			d.datacl,
+			u.usename
+		FROM pg_database d
+			LEFT JOIN pg_user u ON u.usesysid = d.datdba
+		WHERE d.datname = '%s'
+	`, target.database)
+	selectErr := client.QueryRow(selectQuery).Scan(&database, &acl, &owner)
+	if selectErr == sql.ErrNoRows {
+		return map[string][]string{}, nil
+	} else if selectErr != nil {
+		return nil, selectErr
+	}
+
+	if acl == nil {
+		acl = []string{"=T/"}
+		if owner.Valid {
+			quotedOwner := fmt.Sprintf(`"%s"`, strings.ReplaceAll(owner.String, `"`, `""`))
+			acl = []string{"=T/" + quotedOwner, quotedOwner + "=CT/" + quotedOwner}
+		}
+	}
+
+	privileges, parseErr := redshiftAclGranteePrivileges(acl, grantee)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	return map[string][]string{database: privileges}, nil
+}
+
+// Reads the default privileges the grantee receives on objects the owner creates
+// later, in one schema or (without one) anywhere in the database.
+func redshiftReadDefaultPrivileges(client *Client, owner string, schema string, objectType string, grantee redshiftGrantee) ([]string, error) {
+	if client.supportsSvvPrivileges() {
+		return redshiftReadSvvDefaultPrivileges(client.db, owner, schema, objectType, grantee)
+	}
+
+	namespaceFilter := "AND d.defaclnamespace = 0"
+	if schema != "" {
+		namespaceFilter = fmt.Sprintf("AND n.nspname = '%s'", schema)
+	}
+
+	selectQuery := fmt.Sprintf(`
+		SELECT
+			d.defaclacl
+		FROM pg_default_acl d
+			JOIN pg_user u ON u.usesysid = d.defacluser
+			LEFT JOIN pg_namespace n ON n.oid = d.defaclnamespace
+		WHERE u.usename = '%s'
+			AND d.defaclobjtype = '%s'
+			%s
+	`, owner, redshiftDefaultAclObjectTypes[objectType].aclCode, namespaceFilter)
+
+	var acl pq.StringArray
+	selectErr := client.db.QueryRow(selectQuery).Scan(&acl)
+	if selectErr == sql.ErrNoRows {
+		return []string{}, nil
+	} else if selectErr != nil {
+		return nil, selectErr
+	}
+
+	return redshiftAclGranteePrivileges(acl, grantee)
+}
+
+func redshiftReadSvvDefaultPrivileges(client *sql.DB, owner string, schema string, objectType string, grantee redshiftGrantee) ([]string, error) {
+	selectQuery := fmt.Sprintf(`
+		SELECT
+			LOWER(privilege_type)
+		FROM svv_default_privileges
+		WHERE owner_name = '%s'
+			AND COALESCE(schema_name, '') = '%s'
+			AND object_type = '%s'
+			%s
+	`, owner, schema, redshiftDefaultAclObjectTypes[objectType].svvType, redshiftSvvIdentityFilter("grantee_type", "grantee_name", grantee))
+	rows, selectErr := client.Query(selectQuery)
+	if selectErr != nil {
+		return nil, selectErr
+	}
+
+	defer rows.Close()
+	privileges := []string{}
+	for rows.Next() {
+		var privilege string
+		if selectRowErr := rows.Scan(&privilege); selectRowErr != nil {
+			return nil, selectRowErr
+		}
+		if !stringInList(privilege, privileges) {
+			privileges = append(privileges, privilege)
+		}
+	}
+
+	return privileges, rows.Err()
+}
+
+func redshiftReadSvvPrivileges(client *sql.DB, target redshiftGrantTarget, grantee redshiftGrantee) (map[string][]string, error) {
+	var selectQuery string
+	switch target.objectType {
+	case "datashare":
+		selectQuery = fmt.Sprintf(`
+			SELECT
+				datashare_name,
+				LOWER(privilege_type)
+			FROM svv_datashare_privileges
+			WHERE true
+				%s
+				%s
+		`, redshiftObjectFilter("datashare_name", target.objects), redshiftSvvIdentityFilter("identity_type", "identity_name", grantee))
+	case "schema":
+		selectQuery = fmt.Sprintf(`
+			SELECT
+				namespace_name,
+				LOWER(privilege_type)
+			FROM svv_schema_privileges
+			WHERE namespace_name = '%s'
+				%s
+		`, target.schema, redshiftSvvIdentityFilter("identity_type", "identity_name", grantee))
+	case "table", "view":
+		// every table is listed, including those the grantee holds nothing on
+		selectQuery = fmt.Sprintf(`
+			SELECT
+				c.relname,
+				LOWER(p.privilege_type)
+			FROM pg_class c
+				JOIN pg_namespace n ON n.oid = c.relnamespace
+				LEFT JOIN svv_relation_privileges p ON p.namespace_name = n.nspname
+					AND p.relation_name = c.relname
+					%s
+			WHERE c.relkind IN ('r', 'v')
+				AND n.nspname = '%s'
+				%s
+		`, redshiftSvvIdentityFilter("p.identity_type", "p.identity_name", grantee), target.schema, redshiftObjectFilter("c.relname", target.objects))
+	case "function", "procedure":
+		// svv_function_privileges mixes both kinds, pg_proc_info tells them apart
+		selectQuery = fmt.Sprintf(`
+			SELECT
+				p.proname + '(' + oidvectortypes(p.proargtypes) + ')',
+				LOWER(f.privilege_type)
+			FROM pg_proc_info p
+				JOIN pg_namespace n ON n.oid = p.pronamespace
+				LEFT JOIN svv_function_privileges f ON f.namespace_name = n.nspname
+					AND f.function_name = p.proname
+					AND f.argument_types = oidvectortypes(p.proargtypes)
+					%s
+			WHERE p.prokind = '%s'
+				AND n.nspname = '%s'
+				%s
+		`, redshiftSvvIdentityFilter("f.identity_type", "f.identity_name", grantee), redshiftProkinds[target.objectType], target.schema, redshiftObjectFilter("p.proname + '(' + oidvectortypes(p.proargtypes) + ')'", target.objects))
+	default:
+		return nil, fmt.Errorf("Unsupported object type %s", target.objectType)
+	}
+
+	rows, selectErr := client.Query(selectQuery)
+	if selectErr != nil {
+		return nil, selectErr
+	}
+
+	defer rows.Close()
+	privileges := make(map[string][]string)
+	for _, object := range target.objects {
+		privileges[object] = []string{}
+	}
+	for rows.Next() {
+		var object string
+		var privilege sql.NullString
+		if selectRowErr := rows.Scan(&object, &privilege); selectRowErr != nil {
+			return nil, selectRowErr
+		}
+		if _, ok := privileges[object]; !ok {
+			privileges[object] = []string{}
+		}
+		if privilege.Valid && !stringInList(privilege.String, privileges[object]) {
+			privileges[object] = append(privileges[object], privilege.String)
+		}
+	}
+
+	return privileges, rows.Err()
+}
+
+func redshiftSvvIdentityFilter(typeColumn string, nameColumn string, grantee redshiftGrantee) string {
+	if grantee.granteeType == "public" {
+		return fmt.Sprintf("AND %s = 'public'", typeColumn)
+	}
+	return fmt.Sprintf("AND %s = '%s' AND %s = '%s'", typeColumn, grantee.granteeType, nameColumn, grantee.name)
+}
+
+func redshiftObjectFilter(column string, objects []string) string {
+	if len(objects) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("AND %s IN ('%s')", column, strings.Join(objects, "','"))
+}
+
+// Picks the privileges of a single grantee out of an ACL such as
+// {"group analysts=r/etl",etl=arwdRxtDPA/etl}.
+func redshiftAclGranteePrivileges(acl []string, grantee redshiftGrantee) ([]string, error) {
+	items, parseErr := parseRedshiftAcl(acl)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	privileges := []string{}
+	for _, item := range items {
+		if !item.matches(grantee) {
+			continue
+		}
+		for _, privilege := range item.privileges {
+			if !stringInList(privilege, privileges) {
+				privileges = append(privileges, privilege)
+			}
+		}
+	}
+	return privileges, nil
+}
+
+// The privileges held on every one of the objects, i.e. what a grant covering
+// all of them can be said to still hold.
+func redshiftCommonPrivileges(privileges map[string][]string) []string {
+	var common []string
+	first := true
+	for _, objectPrivileges := range privileges {
+		if first {
+			common = append(common, objectPrivileges...)
+			first = false
+			continue
+		}
+		var kept []string
+		for _, privilege := range common {
+			if stringInList(privilege, objectPrivileges) {
+				kept = append(kept, privilege)
+			}
+		}
+		common = kept
+	}
+	sort.Strings(common)
+	return common
+}
+
+// Checks the declared privileges against every table the grant covers,
+// flipping a privilege to false when any table lacks it so that the next apply
+// grants it again. Returns the tables missing at least one declared privilege.
+func redshiftNonCompliantTables(client *Client, target redshiftGrantTarget, grantee redshiftGrantee, privileges map[string]bool) ([]string, error) {
+	tablePrivileges, selectErr := redshiftReadGrantedPrivileges(client, target, grantee)
+	if selectErr != nil {
+		return nil, selectErr
+	}
+
+	nonCompliantTables := []string{}
+	for table, granted := range tablePrivileges {
+		compliant := true
+		for privilege, declared := range privileges {
+			if declared && !stringInList(privilege, granted) {
+				privileges[privilege] = false
+				compliant = false
+			}
+		}
+		if !compliant {
+			nonCompliantTables = append(nonCompliantTables, table)
+		}
+	}
+	sort.Strings(nonCompliantTables)
+
+	return nonCompliantTables, nil
+}
+
+// The tables a table grant without an owner applies to: those of the listed
+// tables that exist, or the tables and views of the schema currently matching
+// table_pattern.
+func redshiftGrantTableTargets(client interface{ Query(string, ...interface{}) (*sql.Rows, error) }, d *schema.ResourceData) ([]string, error) {
+	schemaName := d.Get("schema").(string)
+	if tables := usersSetToList(d.Get("tables")); len(tables) > 0 {
+		return redshiftSchemaTables(client, schemaName, tables)
+	}
+
+	// an empty pattern would match, and grant on, every table of the schema
+	tablePattern := d.Get("table_pattern").(string)
+	if tablePattern == "" {
+		return nil, fmt.Errorf("One of tables or table_pattern must be set")
+	}
+
+	pattern, patternErr := regexp.Compile(tablePattern)
+	if patternErr != nil {
+		return nil, patternErr
+	}
+
+	tables, tablesErr := redshiftSchemaTables(client, schemaName, nil)
+	if tablesErr != nil {
+		return nil, tablesErr
+	}
+
+	targets := []string{}
+	for _, table := range tables {
+		if pattern.MatchString(table) {
+			targets = append(targets, table)
+		}
+	}
+
+	return targets, nil
+}
+
+// Lists the tables and views of a schema, only those of the given tables that
+// exist when any are given.
+func redshiftSchemaTables(client interface{ Query(string, ...interface{}) (*sql.Rows, error) }, schemaName string, tables []string) ([]string, error) {
+	selectQuery := fmt.Sprintf(`
+		SELECT
+			c.relname
+		FROM pg_class c
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN ('r', 'v')
+			AND n.nspname = '%s'
+			%s
+		ORDER BY c.relname
+	`, schemaName, redshiftObjectFilter("c.relname", tables))
+	rows, selectErr := client.Query(selectQuery)
+	if selectErr != nil {
+		return nil, selectErr
+	}
+
+	defer rows.Close()
+	existing := []string{}
+	for rows.Next() {
+		var table string
+		if selectRowErr := rows.Scan(&table); selectRowErr != nil {
+			return nil, selectRowErr
+		}
+		existing = append(existing, table)
+	}
+
+	return existing, rows.Err()
+}
+
+// Reads the column-level privileges the grantee holds on a table, as the
+// columns held per privilege.
+func redshiftReadColumnPrivileges(client *Client, schema string, table string, grantee redshiftGrantee) (map[string][]string, error) {
+	privileges := make(map[string][]string)
+
+	if client.supportsSvvPrivileges() {
+		selectQuery := fmt.Sprintf(`
+			SELECT
+				LOWER(privilege_type),
+				column_name
+			FROM svv_attribute_privileges
+			WHERE namespace_name = '%s'
+				AND relation_name = '%s'
+				%s
+		`, schema, table, redshiftSvvIdentityFilter("identity_type", "identity_name", grantee))
+		rows, selectErr := client.db.Query(selectQuery)
+		if selectErr != nil {
+			return nil, selectErr
+		}
+
+		defer rows.Close()
+		for rows.Next() {
+			var privilege string
+			var column string
+			if selectRowErr := rows.Scan(&privilege, &column); selectRowErr != nil {
+				return nil, selectRowErr
+			}
+			privileges[privilege] = append(privileges[privilege], column)
+		}
+		return privileges, rows.Err()
+	}
+
+	selectQuery := fmt.Sprintf(`
+		SELECT
+			a.attname,
+			a.attacl
+		FROM pg_attribute_info a
+			JOIN pg_class c ON c.oid = a.attrelid
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = '%s'
+			AND c.relname = '%s'
+			AND a.attnum > 0
+			AND a.attacl IS NOT NULL
+	`, schema, table)
+	rows, selectErr := client.db.Query(selectQuery)
+	if selectErr != nil {
+		return nil, selectErr
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var column string
+		var acl pq.StringArray
+		if selectRowErr := rows.Scan(&column, &acl); selectRowErr != nil {
+			return nil, selectRowErr
+		}
+		columnPrivileges, parseErr := redshiftAclGranteePrivileges(acl, grantee)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		for _, privilege := range columnPrivileges {
+			privileges[privilege] = append(privileges[privilege], column)
+		}
+	}
+
+	return privileges, rows.Err()
+}
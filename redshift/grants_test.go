@@ -0,0 +1,90 @@
+package redshift
+
+import (
+	"testing"
+)
+
+func TestRedshiftNormalizeFunctionSignature(t *testing.T) {
+	cases := []struct {
+		signature string
+		expected  string
+	}{
+		{signature: "f_add(integer, integer)", expected: "f_add(integer, integer)"},
+		{signature: "F_Add(INT, int4)", expected: "f_add(integer, integer)"},
+		{signature: "  f_add ( int , int )  ", expected: "f_add(integer, integer)"},
+		{signature: "f_now()", expected: "f_now()"},
+		{signature: "f_now( )", expected: "f_now()"},
+		{signature: "f_pad(varchar(20), char(3))", expected: "f_pad(character varying, character)"},
+		{signature: "f_round(numeric(10,2), int2)", expected: "f_round(numeric, smallint)"},
+		{signature: "f_scale(float, float4, float8)", expected: "f_scale(double precision, real, double precision)"},
+		{signature: "f_at(timestamptz, timestamp)", expected: "f_at(timestamp with time zone, timestamp without time zone)"},
+		{signature: "f_flag(bool, BIGINT)", expected: "f_flag(boolean, bigint)"},
+		{signature: "f_label(character varying(256))", expected: "f_label(character varying)"},
+		{signature: `"F_Add"(int)`, expected: "F_Add(integer)"},
+		{signature: `"f add"()`, expected: "f add()"},
+		{signature: `"say ""hi"""(varchar)`, expected: `say "hi"(character varying)`},
+		{signature: `"f(x)" (int4)`, expected: "f(x)(integer)"},
+	}
+
+	for _, c := range cases {
+		normalized, normalizeErr := redshiftNormalizeFunctionSignature(c.signature)
+		if normalizeErr != nil {
+			t.Errorf("redshiftNormalizeFunctionSignature(%q) failed: %s", c.signature, normalizeErr)
+			continue
+		}
+		if normalized != c.expected {
+			t.Errorf("redshiftNormalizeFunctionSignature(%q) = %q, expected %q", c.signature, normalized, c.expected)
+		}
+	}
+}
+
+func TestRedshiftNormalizeFunctionSignatureInvalid(t *testing.T) {
+	cases := []string{
+		"",
+		"f_add",
+		"(integer)",
+		"f_add(integer",
+		`"f_add(integer)`,
+		`"f_add" integer`,
+	}
+
+	for _, signature := range cases {
+		if normalized, normalizeErr := redshiftNormalizeFunctionSignature(signature); normalizeErr == nil {
+			t.Errorf("redshiftNormalizeFunctionSignature(%q) = %q, should have failed", signature, normalized)
+		}
+	}
+}
+
+func TestRedshiftGrantTargetOnClause(t *testing.T) {
+	cases := []struct {
+		target   redshiftGrantTarget
+		expected string
+	}{
+		{
+			target:   redshiftGrantTarget { objectType: "function", schema: "udfs", objects: []string{"f_add(integer, integer)"} },
+			expected: "FUNCTION udfs.f_add(integer, integer)",
+		},
+		{
+			target:   redshiftGrantTarget { objectType: "function", schema: "udfs", objects: []string{"F_Add(integer)", "f add()"} },
+			expected: `FUNCTION udfs."F_Add"(integer), udfs."f add"()`,
+		},
+		{
+			target:   redshiftGrantTarget { objectType: "procedure", schema: "udfs", objects: []string{`say "hi"(character varying)`} },
+			expected: `PROCEDURE udfs."say ""hi"""(character varying)`,
+		},
+		{
+			target:   redshiftGrantTarget { objectType: "function", schema: "udfs" },
+			expected: "ALL FUNCTIONS IN SCHEMA udfs",
+		},
+		{
+			target:   redshiftGrantTarget { objectType: "table", schema: "sales", objects: []string{"orders", "returns"} },
+			expected: "TABLE sales.orders, sales.returns",
+		},
+	}
+
+	for _, c := range cases {
+		if onClause := c.target.onClause(); onClause != c.expected {
+			t.Errorf("onClause(%+v) = %q, expected %q", c.target, onClause, c.expected)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var redshiftImportLookupQueries = map[string]string {
+	"user":   "SELECT usesysid FROM pg_user WHERE usename = '%s'",
+	"owner":  "SELECT usesysid FROM pg_user WHERE usename = '%s'",
+	"group":  "SELECT grosysid FROM pg_group WHERE groname = '%s'",
+	"schema": "SELECT oid FROM pg_namespace WHERE nspname = '%s'",
+	"role":   "SELECT role_id FROM svv_roles WHERE role_name = '%s'",
+
+	"external_schema": "SELECT esoid FROM svv_external_schemas WHERE schemaname = '%s'",
+}
+
+var redshiftGrantNameQueries = map[string]string {
+	"user":   "SELECT usename FROM pg_user WHERE usesysid = %s",
+	"owner":  "SELECT usename FROM pg_user WHERE usesysid = %s",
+	"group":  "SELECT groname FROM pg_group WHERE grosysid = %s",
+	"schema": "SELECT nspname FROM pg_namespace WHERE oid = %s",
+	"role":   "SELECT role_name FROM svv_roles WHERE role_id = %s",
+}
+
+func redshiftResolveImportId(client *sql.DB, kind string, id string) (string, error) {
+	if _, atoiErr := strconv.Atoi(id); atoiErr == nil {
+		return id, nil
+	}
+
+	var resolvedId string
+	selectQuery := fmt.Sprintf(redshiftImportLookupQueries[kind], id)
+	selectErr := client.QueryRow(selectQuery).Scan(&resolvedId)
+
+	if selectErr != nil {
+		log.Println("error | redshiftResolveImportId | selectErr |", selectErr)
+		if selectErr == sql.ErrNoRows {
+			return "", fmt.Errorf("Cannot import %s %s: not found", kind, id)
+		} else {
+			return "", selectErr
+		}
+	}
+
+	return resolvedId, nil
+}
+
+// Grants are imported either by their raw ID (e.g. grosysid-nspoid-usesysid) or by
+// a readable ID listing the same parts by name (e.g. group:analysts/schema:sales/owner:etl).
+func redshiftGrantImporter(kinds []string, read func(*Client, *schema.ResourceData) error) schema.StateFunc {
+	return func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		client := meta.(*Client)
+		importId := d.Id()
+
+		var ids []string
+		if strings.Contains(importId, ":") {
+			names, parseErr := parseImportId(importId)
+			if parseErr != nil {
+				log.Println("error | redshiftGrantImporter | parseErr |", parseErr)
+				return nil, parseErr
+			}
+
+			for _, kind := range kinds {
+				name, ok := names[kind]
+				if !ok {
+					return nil, fmt.Errorf("Cannot import %s: missing %s", importId, kind)
+				}
+
+				id, resolveErr := redshiftResolveImportId(client.db, kind, name)
+				if resolveErr != nil {
+					return nil, resolveErr
+				}
+				ids = append(ids, id)
+			}
+		} else {
+			ids = strings.Split(importId, "-")
+			if len(ids) != len(kinds) {
+				return nil, fmt.Errorf("Cannot import %s: expected ID in the form %s", importId, strings.Join(kinds, "-"))
+			}
+		}
+
+		d.SetId(strings.Join(ids, "-"))
+		d.Set("cascade", false)
+		if readErr := read(client, d); readErr != nil {
+			return nil, readErr
+		}
+
+		if d.Id() == "" {
+			return nil, fmt.Errorf("Cannot import %s: grant does not exist", importId)
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+func parseImportId(importId string) (map[string]string, error) {
+	names := make(map[string]string)
+
+	for _, part := range strings.Split(importId, "/") {
+		kv := strings.SplitN(part, ":", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, fmt.Errorf("Cannot import %s: expected parts in the form kind:name", importId)
+		}
+		names[kv[0]] = kv[1]
+	}
+
+	return names, nil
+}
@@ -2,8 +2,10 @@ package redshift
 
 import (
     "log"
+    "regexp"
 
     "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+    "github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func Provider() *schema.Provider {
@@ -42,18 +44,42 @@ func Provider() *schema.Provider {
                 Description: "database",
                 Required:    true,
             },
+            "svv_privileges_min_version": {
+                Type:         schema.TypeString,
+                Description:  "first Redshift version whose grants are read from the svv_*_privileges views, older clusters have their catalog ACLs decoded",
+                Optional:     true,
+                Default:      "1.0.38698",
+                ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\d+\.\d+\.\d+$`), "must be a version such as 1.0.38698"),
+            },
         },
         ResourcesMap: map[string]*schema.Resource {
+            "redshift_grant":               resourceRedshiftGrant(),
+            "redshift_grant_columns":       resourceRedshiftGrantColumns(),
+            "redshift_grant_database":      resourceRedshiftGrantDatabase(),
             "redshift_grant_table_group":   resourceRedshiftGrantTableGroup(),
             "redshift_grant_table_user":    resourceRedshiftGrantTableUser(),
+            "redshift_grant_table_role":    resourceRedshiftGrantTableRole(),
             "redshift_grant_schema_group":  resourceRedshiftGrantSchemaGroup(),
             "redshift_grant_schema_user":   resourceRedshiftGrantSchemaUser(),
+            "redshift_grant_schema_role":   resourceRedshiftGrantSchemaRole(),
+            "redshift_default_privileges":  resourceRedshiftDefaultPrivileges(),
+            "redshift_external_schema":     resourceRedshiftExternalSchema(),
             "redshift_group":               resourceRedshiftGroup(),
+            "redshift_group_membership":    resourceRedshiftGroupMembership(),
+            "redshift_group_role_migration": resourceRedshiftGroupRoleMigration(),
+            "redshift_role":                resourceRedshiftRole(),
+            "redshift_role_grant":          resourceRedshiftRoleGrant(),
+            "redshift_role_system_permissions": resourceRedshiftRoleSystemPermissions(),
             "redshift_schema":              resourceRedshiftSchema(),
             "redshift_user":                resourceRedshiftUser(),
             "redshift_user_password":       resourceRedshiftUserPassword(),
             "redshift_user_password_association": resourceRedshiftUserPasswordAssociation(),
         },
+        DataSourcesMap: map[string]*schema.Resource {
+            "redshift_schema":              dataSourceRedshiftSchema(),
+            "redshift_user":                dataSourceRedshiftUser(),
+            "redshift_users":               dataSourceRedshiftUsers(),
+        },
         ConfigureFunc: providerConfigure,
     }
 }
@@ -77,9 +103,25 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
     db := client.db
 
     if err = db.Ping(); err != nil {
-        log.Println("error | provider | providerConfigure | %v", err)
+        log.Println("error | provider | providerConfigure |", err)
+        return nil, err
+    }
+
+    if client.svvPrivilegesVersion, err = parseRedshiftVersionNumber(d.Get("svv_privileges_min_version").(string)); err != nil {
         return nil, err
     }
 
+    var version string
+    if err = db.QueryRow("SELECT version()").Scan(&version); err != nil {
+        log.Println("error | provider | providerConfigure |", err)
+        return nil, err
+    }
+
+    // an unknown version only costs the svv views, grants are still read from the ACLs
+    if client.version, err = parseRedshiftVersion(version); err != nil {
+        log.Println("warning | provider | providerConfigure | falling back to catalog ACLs |", err)
+    }
+    log.Println("info | provider | providerConfigure |", version)
+
     return client, nil
 }
@@ -0,0 +1,256 @@
+package redshift
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+
+func resourceRedshiftDefaultPrivileges() *schema.Resource {
+	return &schema.Resource {
+		Create:        resourceRedshiftDefaultPrivilegesCreate,
+		Read:          resourceRedshiftDefaultPrivilegesRead,
+		Update:        resourceRedshiftDefaultPrivilegesUpdate,
+		Delete:        resourceRedshiftDefaultPrivilegesDelete,
+		CustomizeDiff: resourceRedshiftDefaultPrivilegesCustomizeDiff,
+		Importer: &schema.ResourceImporter {
+			State: resourceRedshiftDefaultPrivilegesImport,
+		},
+		Schema: map[string]*schema.Schema {
+			"owner": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "user whose future objects receive the privileges, defaults to the provider's user",
+			},
+			"schema": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "schema the defaults apply to, the whole database when omitted",
+			},
+			"object_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"tables", "functions", "procedures"}, false),
+			},
+			"grantee_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"user", "group", "role"}, false),
+			},
+			"grantee": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"privileges": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema { Type: schema.TypeString },
+				Required: true,
+				MinItems: 1,
+			},
+		},
+	}
+}
+
+func resourceRedshiftDefaultPrivilegesCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	if _, ok := d.GetOk("owner"); !ok {
+		var owner string
+		if selectErr := client.db.QueryRow("SELECT current_user").Scan(&owner); selectErr != nil {
+			log.Println("error | resourceRedshiftDefaultPrivilegesCreate | selectErr |", selectErr)
+			return selectErr
+		}
+		d.Set("owner", owner)
+	}
+
+	owner, schema, objectType, grantee := redshiftDefaultPrivilegesScope(d)
+	privileges := usersSetToList(d.Get("privileges"))
+
+	tx, txBeginErr := client.db.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftDefaultPrivilegesCreate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	revokeStatement := redshiftDefaultPrivilegesStatement("REVOKE", redshiftDefaultAclObjectTypes[objectType].privileges, owner, schema, objectType, grantee)
+	if _, revokeErr := tx.Exec(revokeStatement); revokeErr != nil {
+		log.Println("error | resourceRedshiftDefaultPrivilegesCreate | revokeErr |", revokeErr)
+		tx.Rollback()
+		return revokeErr
+	}
+
+	grantStatement := redshiftDefaultPrivilegesStatement("GRANT", privileges, owner, schema, objectType, grantee)
+	if _, grantErr := tx.Exec(grantStatement); grantErr != nil {
+		log.Println("error | resourceRedshiftDefaultPrivilegesCreate | grantErr |", grantErr)
+		tx.Rollback()
+		return grantErr
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftDefaultPrivilegesCreate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	id := strings.Join([]string{owner, schema, objectType, fmt.Sprintf("%s:%s", grantee.granteeType, grantee.name)}, "/")
+	d.SetId(id)
+	return redshiftDefaultPrivilegesRead(client, d)
+}
+
+func resourceRedshiftDefaultPrivilegesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	return redshiftDefaultPrivilegesRead(client, d)
+}
+
+// Imports by the resource ID owner/schema/object_type/grantee_type:grantee, the
+// schema being empty for defaults that apply to the whole database.
+func resourceRedshiftDefaultPrivilegesImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client)
+	importId := d.Id()
+
+	parts := strings.Split(importId, "/")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("Cannot import %s: expected ID in the form owner/schema/object_type/grantee_type:grantee", importId)
+	}
+	grantee := strings.SplitN(parts[3], ":", 2)
+	if len(grantee) != 2 || grantee[1] == "" {
+		return nil, fmt.Errorf("Cannot import %s: expected grantee in the form grantee_type:grantee", importId)
+	}
+	if _, ok := redshiftDefaultAclObjectTypes[parts[2]]; !ok {
+		return nil, fmt.Errorf("Cannot import %s: unsupported object type %s", importId, parts[2])
+	}
+
+	d.Set("owner", parts[0])
+	d.Set("schema", parts[1])
+	d.Set("object_type", parts[2])
+	d.Set("grantee_type", grantee[0])
+	d.Set("grantee", grantee[1])
+
+	if readErr := redshiftDefaultPrivilegesRead(client, d); readErr != nil {
+		return nil, readErr
+	}
+
+	if d.Id() == "" {
+		return nil, fmt.Errorf("Cannot import %s: default privileges do not exist", importId)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRedshiftDefaultPrivilegesUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	owner, schema, objectType, grantee := redshiftDefaultPrivilegesScope(d)
+	grants, revokes := redshiftChangedPrivilegesSet(d, "privileges")
+
+	tx, txBeginErr := client.db.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftDefaultPrivilegesUpdate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	if len(revokes) > 0 {
+		revokeStatement := redshiftDefaultPrivilegesStatement("REVOKE", revokes, owner, schema, objectType, grantee)
+		if _, revokeErr := tx.Exec(revokeStatement); revokeErr != nil {
+			log.Println("error | resourceRedshiftDefaultPrivilegesUpdate | revokeErr |", revokeErr)
+			tx.Rollback()
+			return revokeErr
+		}
+	}
+
+	if len(grants) > 0 {
+		grantStatement := redshiftDefaultPrivilegesStatement("GRANT", grants, owner, schema, objectType, grantee)
+		if _, grantErr := tx.Exec(grantStatement); grantErr != nil {
+			log.Println("error | resourceRedshiftDefaultPrivilegesUpdate | grantErr |", grantErr)
+			tx.Rollback()
+			return grantErr
+		}
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftDefaultPrivilegesUpdate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	return redshiftDefaultPrivilegesRead(client, d)
+}
+
+func resourceRedshiftDefaultPrivilegesDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	owner, schema, objectType, grantee := redshiftDefaultPrivilegesScope(d)
+
+	tx, txBeginErr := client.db.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftDefaultPrivilegesDelete | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	revokeStatement := redshiftDefaultPrivilegesStatement("REVOKE", redshiftDefaultAclObjectTypes[objectType].privileges, owner, schema, objectType, grantee)
+	if _, revokeErr := tx.Exec(revokeStatement); revokeErr != nil {
+		log.Println("error | resourceRedshiftDefaultPrivilegesDelete | revokeErr |", revokeErr)
+		tx.Rollback()
+		return revokeErr
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftDefaultPrivilegesDelete | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	return nil
+}
+
+func resourceRedshiftDefaultPrivilegesCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("privileges") {
+		return nil
+	}
+
+	objectType := d.Get("object_type").(string)
+	allowed := redshiftDefaultAclObjectTypes[objectType].privileges
+	for _, privilege := range usersSetToList(d.Get("privileges")) {
+		if !stringInList(privilege, allowed) {
+			return fmt.Errorf("Privilege %s is not valid for %s, expected one of %s", privilege, objectType, strings.Join(allowed, ", "))
+		}
+	}
+
+	return nil
+}
+
+func redshiftDefaultPrivilegesScope(d *schema.ResourceData) (string, string, string, redshiftGrantee) {
+	grantee := redshiftGrantee {
+		granteeType: d.Get("grantee_type").(string),
+		name:        d.Get("grantee").(string),
+	}
+	return d.Get("owner").(string), d.Get("schema").(string), d.Get("object_type").(string), grantee
+}
+
+func redshiftDefaultPrivilegesRead(client *Client, d *schema.ResourceData) error {
+	owner, schema, objectType, grantee := redshiftDefaultPrivilegesScope(d)
+
+	privileges, selectErr := redshiftReadDefaultPrivileges(client, owner, schema, objectType, grantee)
+	if selectErr != nil {
+		log.Println("error | redshiftDefaultPrivilegesRead | selectErr |", selectErr)
+		return selectErr
+	}
+
+	if len(privileges) == 0 {
+		log.Println("info | redshiftDefaultPrivilegesRead | default privileges not found |", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	sort.Strings(privileges)
+	d.Set("privileges", privileges)
+
+	return nil
+}
@@ -0,0 +1,463 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var redshiftExternalSchemaSources = []string{
+	"data_catalog_source",
+	"hive_metastore_source",
+	"postgres_source",
+	"mysql_source",
+	"kinesis_source",
+	"msk_source",
+}
+
+
+func resourceRedshiftExternalSchema() *schema.Resource {
+	return &schema.Resource {
+		Create: resourceRedshiftExternalSchemaCreate,
+		Read:   resourceRedshiftExternalSchemaRead,
+		Update: resourceRedshiftExternalSchemaUpdate,
+		Delete: resourceRedshiftExternalSchemaDelete,
+		Importer: &schema.ResourceImporter {
+			State: resourceRedshiftExternalSchemaImport,
+		},
+		Schema: map[string]*schema.Schema {
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"database_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "external database the schema points at, required for every source except kinesis and msk",
+			},
+			"data_catalog_source": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				ExactlyOneOf: redshiftExternalSchemaSources,
+				Elem: &schema.Resource {
+					Schema: map[string]*schema.Schema {
+						"region": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"iam_role_arns": redshiftExternalSchemaIamRoleArnsSchema(),
+						"catalog_role_arns": {
+							Type:     schema.TypeList,
+							Elem:     &schema.Schema { Type: schema.TypeString },
+							Optional: true,
+							ForceNew: true,
+						},
+						"create_external_database_if_not_exists": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+			"hive_metastore_source": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource {
+					Schema: map[string]*schema.Schema {
+						"hostname":      redshiftExternalSchemaHostnameSchema(),
+						"port":          redshiftExternalSchemaPortSchema(),
+						"iam_role_arns": redshiftExternalSchemaIamRoleArnsSchema(),
+					},
+				},
+			},
+			"postgres_source": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource {
+					Schema: map[string]*schema.Schema {
+						"hostname":      redshiftExternalSchemaHostnameSchema(),
+						"port":          redshiftExternalSchemaPortSchema(),
+						"iam_role_arns": redshiftExternalSchemaIamRoleArnsSchema(),
+						"secret_arn":    redshiftExternalSchemaSecretArnSchema(),
+						"schema": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+			"mysql_source": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource {
+					Schema: map[string]*schema.Schema {
+						"hostname":      redshiftExternalSchemaHostnameSchema(),
+						"port":          redshiftExternalSchemaPortSchema(),
+						"iam_role_arns": redshiftExternalSchemaIamRoleArnsSchema(),
+						"secret_arn":    redshiftExternalSchemaSecretArnSchema(),
+					},
+				},
+			},
+			"kinesis_source": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource {
+					Schema: map[string]*schema.Schema {
+						"iam_role_arns": redshiftExternalSchemaIamRoleArnsSchema(),
+					},
+				},
+			},
+			"msk_source": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource {
+					Schema: map[string]*schema.Schema {
+						"cluster_arn": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"authentication": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							Default:      "none",
+							ValidateFunc: validation.StringInSlice([]string{"none", "iam"}, false),
+						},
+						"iam_role_arns": redshiftExternalSchemaIamRoleArnsSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func redshiftExternalSchemaIamRoleArnsSchema() *schema.Schema {
+	return &schema.Schema {
+		Type:        schema.TypeList,
+		Elem:        &schema.Schema { Type: schema.TypeString },
+		Required:    true,
+		ForceNew:    true,
+		MinItems:    1,
+		Description: "IAM roles chained in order, or [\"default\"] for the cluster's default role",
+	}
+}
+
+func redshiftExternalSchemaHostnameSchema() *schema.Schema {
+	return &schema.Schema {
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+}
+
+func redshiftExternalSchemaPortSchema() *schema.Schema {
+	return &schema.Schema {
+		Type:     schema.TypeInt,
+		Optional: true,
+		ForceNew: true,
+	}
+}
+
+func redshiftExternalSchemaSecretArnSchema() *schema.Schema {
+	return &schema.Schema {
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+}
+
+func resourceRedshiftExternalSchemaCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+	name := d.Get("name")
+
+	sourceClause, sourceErr := redshiftExternalSchemaSourceClause(d)
+	if sourceErr != nil {
+		log.Println("error | resourceRedshiftExternalSchemaCreate | sourceErr |", sourceErr)
+		return sourceErr
+	}
+
+	tx, txBeginErr := client.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftExternalSchemaCreate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	createStatement := fmt.Sprintf("CREATE EXTERNAL SCHEMA %s %s", name, sourceClause)
+	if _, createErr := tx.Exec(createStatement); createErr != nil {
+		log.Println("error | resourceRedshiftExternalSchemaCreate | createErr |", createErr)
+		tx.Rollback()
+		return createErr
+	}
+
+	if owner, ok := d.GetOk("owner"); ok {
+		alterOwnerStatement := fmt.Sprintf("ALTER SCHEMA %s OWNER TO %s", name, owner)
+		if _, alterOwnerErr := tx.Exec(alterOwnerStatement); alterOwnerErr != nil {
+			log.Println("error | resourceRedshiftExternalSchemaCreate | alterOwnerErr |", alterOwnerErr)
+			tx.Rollback()
+			return alterOwnerErr
+		}
+	}
+
+	var id string
+	selectQuery := fmt.Sprintf("SELECT esoid FROM svv_external_schemas WHERE schemaname = '%s'", name)
+	selectErr := tx.QueryRow(selectQuery).Scan(&id)
+	if selectErr != nil {
+		log.Println("error | resourceRedshiftExternalSchemaCreate | selectErr |", selectErr)
+		tx.Rollback()
+		return selectErr
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftExternalSchemaCreate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	d.SetId(id)
+	return redshiftExternalSchemaRead(client, d)
+}
+
+func resourceRedshiftExternalSchemaRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+	return redshiftExternalSchemaRead(client, d)
+}
+
+// The source block is not read back, so an imported schema only matches a
+// configuration whose source block is ignored with lifecycle.ignore_changes.
+func resourceRedshiftExternalSchemaImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client).db
+	importId := d.Id()
+
+	id, resolveErr := redshiftResolveImportId(client, "external_schema", importId)
+	if resolveErr != nil {
+		log.Println("error | resourceRedshiftExternalSchemaImport | resolveErr |", resolveErr)
+		return nil, resolveErr
+	}
+
+	d.SetId(id)
+	if readErr := redshiftExternalSchemaRead(client, d); readErr != nil {
+		return nil, readErr
+	}
+
+	if d.Id() == "" {
+		return nil, fmt.Errorf("Cannot import external schema %s: not found", importId)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRedshiftExternalSchemaUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+
+	tx, txBeginErr := client.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftExternalSchemaUpdate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	if d.HasChange("name") {
+		oldName, newName := d.GetChange("name")
+		alterNameStatement := fmt.Sprintf("ALTER SCHEMA %s RENAME TO %s", oldName, newName)
+		if _, alterNameErr := tx.Exec(alterNameStatement); alterNameErr != nil {
+			log.Println("error | resourceRedshiftExternalSchemaUpdate | alterNameErr |", alterNameErr)
+			tx.Rollback()
+			return alterNameErr
+		}
+	}
+
+	if d.HasChange("owner") {
+		name := d.Get("name")
+		owner := d.Get("owner")
+		alterOwnerStatement := fmt.Sprintf("ALTER SCHEMA %s OWNER TO %s", name, owner)
+		if _, alterOwnerErr := tx.Exec(alterOwnerStatement); alterOwnerErr != nil {
+			log.Println("error | resourceRedshiftExternalSchemaUpdate | alterOwnerErr |", alterOwnerErr)
+			tx.Rollback()
+			return alterOwnerErr
+		}
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftExternalSchemaUpdate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	return redshiftExternalSchemaRead(client, d)
+}
+
+func resourceRedshiftExternalSchemaDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+	name := d.Get("name")
+
+	tx, txBeginErr := client.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftExternalSchemaDelete | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	dropStatement := fmt.Sprintf("DROP SCHEMA %s", name)
+	if _, dropErr := tx.Exec(dropStatement); dropErr != nil {
+		log.Println("error | resourceRedshiftExternalSchemaDelete | dropErr |", dropErr)
+		tx.Rollback()
+		return dropErr
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftExternalSchemaDelete | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	return nil
+}
+
+func redshiftExternalSchemaSourceClause(d *schema.ResourceData) (string, error) {
+	databaseName := d.Get("database_name").(string)
+	requireDatabase := func(source string) error {
+		if databaseName == "" {
+			return fmt.Errorf("database_name is required for %s", source)
+		}
+		return nil
+	}
+	iamRole := func(source map[string]interface{}) string {
+		iamRoleArns := listToStrings(source["iam_role_arns"])
+		if len(iamRoleArns) == 1 && strings.EqualFold(iamRoleArns[0], "default") {
+			return "IAM_ROLE default"
+		}
+		return fmt.Sprintf("IAM_ROLE '%s'", strings.Join(iamRoleArns, ","))
+	}
+	uri := func(source map[string]interface{}) string {
+		clause := fmt.Sprintf("URI '%s'", source["hostname"])
+		if port := source["port"].(int); port > 0 {
+			clause = fmt.Sprintf("%s PORT %d", clause, port)
+		}
+		return clause
+	}
+
+	if v, ok := d.GetOk("data_catalog_source"); ok {
+		if err := requireDatabase("data_catalog_source"); err != nil {
+			return "", err
+		}
+		source := v.([]interface{})[0].(map[string]interface{})
+		clause := fmt.Sprintf("FROM DATA CATALOG DATABASE '%s'", databaseName)
+		if region := source["region"].(string); region != "" {
+			clause = fmt.Sprintf("%s REGION '%s'", clause, region)
+		}
+		clause = fmt.Sprintf("%s %s", clause, iamRole(source))
+		if catalogRoles := listToStrings(source["catalog_role_arns"]); len(catalogRoles) > 0 {
+			clause = fmt.Sprintf("%s CATALOG_ROLE '%s'", clause, strings.Join(catalogRoles, ","))
+		}
+		if source["create_external_database_if_not_exists"].(bool) {
+			clause = fmt.Sprintf("%s CREATE EXTERNAL DATABASE IF NOT EXISTS", clause)
+		}
+		return clause, nil
+	}
+
+	if v, ok := d.GetOk("hive_metastore_source"); ok {
+		if err := requireDatabase("hive_metastore_source"); err != nil {
+			return "", err
+		}
+		source := v.([]interface{})[0].(map[string]interface{})
+		return fmt.Sprintf("FROM HIVE METASTORE DATABASE '%s' %s %s", databaseName, uri(source), iamRole(source)), nil
+	}
+
+	if v, ok := d.GetOk("postgres_source"); ok {
+		if err := requireDatabase("postgres_source"); err != nil {
+			return "", err
+		}
+		source := v.([]interface{})[0].(map[string]interface{})
+		clause := fmt.Sprintf("FROM POSTGRES DATABASE '%s'", databaseName)
+		if remoteSchema := source["schema"].(string); remoteSchema != "" {
+			clause = fmt.Sprintf("%s SCHEMA '%s'", clause, remoteSchema)
+		}
+		return fmt.Sprintf("%s %s %s SECRET_ARN '%s'", clause, uri(source), iamRole(source), source["secret_arn"]), nil
+	}
+
+	if v, ok := d.GetOk("mysql_source"); ok {
+		if err := requireDatabase("mysql_source"); err != nil {
+			return "", err
+		}
+		source := v.([]interface{})[0].(map[string]interface{})
+		return fmt.Sprintf("FROM MYSQL DATABASE '%s' %s %s SECRET_ARN '%s'", databaseName, uri(source), iamRole(source), source["secret_arn"]), nil
+	}
+
+	if v, ok := d.GetOk("kinesis_source"); ok {
+		source := v.([]interface{})[0].(map[string]interface{})
+		return fmt.Sprintf("FROM KINESIS %s", iamRole(source)), nil
+	}
+
+	if v, ok := d.GetOk("msk_source"); ok {
+		source := v.([]interface{})[0].(map[string]interface{})
+		return fmt.Sprintf("FROM MSK %s AUTHENTICATION %s CLUSTER_ARN '%s'", iamRole(source), source["authentication"], source["cluster_arn"]), nil
+	}
+
+	return "", fmt.Errorf("One of %s must be set", strings.Join(redshiftExternalSchemaSources, ", "))
+}
+
+func redshiftExternalSchemaRead(client *sql.DB, d *schema.ResourceData) error {
+	id := d.Id()
+
+	var name string
+	var owner string
+	var databaseName string
+	selectQuery := fmt.Sprintf(`
+		SELECT
+			s.schemaname,
+			u.usename,
+			COALESCE(s.databasename, '')
+		FROM svv_external_schemas s
+			JOIN pg_user u ON u.usesysid = s.esowner
+		WHERE s.esoid = %s
+	`, id)
+	selectErr := client.QueryRow(selectQuery).Scan(&name, &owner, &databaseName)
+
+	if selectErr != nil {
+		log.Println("error | redshiftExternalSchemaRead | selectErr |", selectErr)
+		if selectErr == sql.ErrNoRows {
+			d.SetId("")
+			return nil
+		} else {
+			return selectErr
+		}
+	}
+
+	d.Set("name", name)
+	d.Set("owner", owner)
+	if databaseName != "" {
+		d.Set("database_name", databaseName)
+	}
+
+	return nil
+}
+
+func listToStrings(list interface{}) []string {
+	var values []string
+	for _, v := range list.([]interface{}) {
+		values = append(values, v.(string))
+	}
+	return values
+}
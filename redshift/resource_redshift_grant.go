@@ -0,0 +1,411 @@
+package redshift
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+
+func resourceRedshiftGrant() *schema.Resource {
+	return &schema.Resource {
+		Create:        resourceRedshiftGrantCreate,
+		Read:          resourceRedshiftGrantRead,
+		Update:        resourceRedshiftGrantUpdate,
+		Delete:        resourceRedshiftGrantDelete,
+		CustomizeDiff: resourceRedshiftGrantCustomizeDiff,
+		Importer: &schema.ResourceImporter {
+			State: resourceRedshiftGrantImport,
+		},
+		Schema: map[string]*schema.Schema {
+			"grantee_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"user", "group", "role", "public"}, false),
+			},
+			"grantee": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "name of the user, group or role, omitted for public",
+			},
+			"object_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"database", "schema", "table", "view", "function", "procedure", "language", "datashare"}, false),
+			},
+			"database": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "database to grant on when object_type is database, defaults to the provider's database",
+			},
+			"schema": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"objects": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema { Type: schema.TypeString },
+				Optional:    true,
+				Description: "tables, views, function signatures, languages or datashares to grant on, all tables or functions of the schema when omitted",
+			},
+			"privileges": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema { Type: schema.TypeString },
+				Required: true,
+				MinItems: 1,
+			},
+			"cascade": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "revoke with CASCADE, also stripping privileges granted onwards through grant options",
+			},
+		},
+	}
+}
+
+func resourceRedshiftGrantCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	if _, ok := d.GetOk("database"); !ok && d.Get("object_type") == "database" {
+		var database string
+		if selectErr := client.db.QueryRow("SELECT current_database()").Scan(&database); selectErr != nil {
+			log.Println("error | resourceRedshiftGrantCreate | selectErr |", selectErr)
+			return selectErr
+		}
+		d.Set("database", database)
+	}
+
+	target, grantee := redshiftGrantTargetAndGrantee(d)
+	privileges := usersSetToList(d.Get("privileges"))
+
+	tx, txBeginErr := client.db.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftGrantCreate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	revokeStatement := redshiftRevokeStatement(redshiftGrantObjectTypes[target.objectType].privileges, target, grantee, d.Get("cascade").(bool))
+	if _, revokeErr := tx.Exec(revokeStatement); revokeErr != nil {
+		log.Println("error | resourceRedshiftGrantCreate | revokeErr |", revokeErr)
+		tx.Rollback()
+		return revokeErr
+	}
+
+	grantStatement := redshiftGrantStatement(privileges, target, grantee)
+	if _, grantErr := tx.Exec(grantStatement); grantErr != nil {
+		log.Println("error | resourceRedshiftGrantCreate | grantErr |", grantErr)
+		tx.Rollback()
+		return grantErr
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftGrantCreate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	d.SetId(redshiftGrantId(target, grantee))
+	return redshiftGrantRead(client, d)
+}
+
+func resourceRedshiftGrantRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	return redshiftGrantRead(client, d)
+}
+
+func resourceRedshiftGrantUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	target, grantee := redshiftGrantTargetAndGrantee(d)
+	grants, revokes := redshiftChangedPrivilegesSet(d, "privileges")
+	cascade := d.Get("cascade").(bool)
+
+	var statements []string
+	if d.HasChange("objects") {
+		oldObjectsSet, _ := d.GetChange("objects")
+		oldObjects := redshiftGrantObjects(target.objectType, oldObjectsSet)
+		allPrivileges := redshiftGrantObjectTypes[target.objectType].privileges
+		privileges := usersSetToList(d.Get("privileges"))
+
+		var removed, kept, added []string
+		for _, object := range oldObjects {
+			if !stringInList(object, target.objects) {
+				removed = append(removed, object)
+			}
+		}
+		for _, object := range target.objects {
+			if stringInList(object, oldObjects) {
+				kept = append(kept, object)
+			} else {
+				added = append(added, object)
+			}
+		}
+
+		oldTarget := target
+		oldTarget.objects = oldObjects
+		if len(oldObjects) == 0 || len(target.objects) == 0 {
+			// a target without objects covers the whole schema, so nothing is kept from it
+			statements = append(statements,
+				redshiftRevokeStatement(allPrivileges, oldTarget, grantee, cascade),
+				redshiftRevokeStatement(allPrivileges, target, grantee, cascade),
+				redshiftGrantStatement(privileges, target, grantee),
+			)
+		} else {
+			if len(removed) > 0 {
+				removedTarget := target
+				removedTarget.objects = removed
+				statements = append(statements, redshiftRevokeStatement(allPrivileges, removedTarget, grantee, cascade))
+			}
+			if len(kept) > 0 {
+				keptTarget := target
+				keptTarget.objects = kept
+				if len(revokes) > 0 {
+					statements = append(statements, redshiftRevokeStatement(revokes, keptTarget, grantee, cascade))
+				}
+				if len(grants) > 0 {
+					statements = append(statements, redshiftGrantStatement(grants, keptTarget, grantee))
+				}
+			}
+			if len(added) > 0 {
+				addedTarget := target
+				addedTarget.objects = added
+				statements = append(statements,
+					redshiftRevokeStatement(allPrivileges, addedTarget, grantee, cascade),
+					redshiftGrantStatement(privileges, addedTarget, grantee),
+				)
+			}
+		}
+	} else {
+		if len(revokes) > 0 {
+			statements = append(statements, redshiftRevokeStatement(revokes, target, grantee, cascade))
+		}
+		if len(grants) > 0 {
+			statements = append(statements, redshiftGrantStatement(grants, target, grantee))
+		}
+	}
+
+	tx, txBeginErr := client.db.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftGrantUpdate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	for _, statement := range statements {
+		if _, grantErr := tx.Exec(statement); grantErr != nil {
+			log.Println("error | resourceRedshiftGrantUpdate | grantErr |", statement, "|", grantErr)
+			tx.Rollback()
+			return grantErr
+		}
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftGrantUpdate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	d.SetId(redshiftGrantId(target, grantee))
+	return redshiftGrantRead(client, d)
+}
+
+func resourceRedshiftGrantDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	target, grantee := redshiftGrantTargetAndGrantee(d)
+
+	tx, txBeginErr := client.db.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftGrantDelete | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	revokeStatement := redshiftRevokeStatement(redshiftGrantObjectTypes[target.objectType].privileges, target, grantee, d.Get("cascade").(bool))
+	if _, revokeErr := tx.Exec(revokeStatement); revokeErr != nil {
+		log.Println("error | resourceRedshiftGrantDelete | revokeErr |", revokeErr)
+		tx.Rollback()
+		return revokeErr
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftGrantDelete | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	return nil
+}
+
+func resourceRedshiftGrantCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	objectType := d.Get("object_type").(string)
+	granteeType := d.Get("grantee_type").(string)
+
+	if d.NewValueKnown("grantee") {
+		if grantee := d.Get("grantee").(string); granteeType == "public" && grantee != "" {
+			return fmt.Errorf("grantee must be omitted when grantee_type is public")
+		} else if granteeType != "public" && grantee == "" {
+			return fmt.Errorf("grantee is required when grantee_type is %s", granteeType)
+		}
+	}
+
+	// every other object type is granted on in the provider's database
+	if d.NewValueKnown("database") && d.Get("database").(string) != "" && objectType != "database" {
+		return fmt.Errorf("database cannot be set when object_type is %s", objectType)
+	}
+
+	if d.NewValueKnown("schema") && d.Get("schema").(string) == "" && redshiftGrantObjectTypes[objectType].requiresSchema {
+		return fmt.Errorf("schema is required when object_type is %s", objectType)
+	}
+
+	if d.NewValueKnown("objects") {
+		if objectType == "function" || objectType == "procedure" {
+			for _, object := range usersSetToList(d.Get("objects")) {
+				if _, signatureErr := redshiftNormalizeFunctionSignature(object); signatureErr != nil {
+					return signatureErr
+				}
+			}
+		}
+
+		objects := d.Get("objects").(*schema.Set).Len()
+		if objects > 0 && (objectType == "database" || objectType == "schema") {
+			return fmt.Errorf("objects cannot be set when object_type is %s", objectType)
+		}
+		// views share GRANT ... ON ALL TABLES with tables, so they can only be granted by name
+		if objects == 0 && (objectType == "view" || objectType == "language" || objectType == "datashare") {
+			return fmt.Errorf("objects is required when object_type is %s", objectType)
+		}
+	}
+
+	if d.NewValueKnown("privileges") {
+		return redshiftValidatePrivileges(objectType, usersSetToList(d.Get("privileges")))
+	}
+
+	return nil
+}
+
+func redshiftGrantTargetAndGrantee(d *schema.ResourceData) (redshiftGrantTarget, redshiftGrantee) {
+	objectType := d.Get("object_type").(string)
+	target := redshiftGrantTarget {
+		objectType: objectType,
+		database:   d.Get("database").(string),
+		schema:     d.Get("schema").(string),
+		objects:    redshiftGrantObjects(objectType, d.Get("objects")),
+	}
+	grantee := redshiftGrantee {
+		granteeType: d.Get("grantee_type").(string),
+		name:        d.Get("grantee").(string),
+	}
+
+	return target, grantee
+}
+
+func redshiftGrantObjects(objectType string, objectsSet interface{}) []string {
+	objects := usersSetToList(objectsSet)
+	if objectType == "function" || objectType == "procedure" {
+		// validated at plan time, overloads are told apart by their argument types
+		for i, object := range objects {
+			if signature, signatureErr := redshiftNormalizeFunctionSignature(object); signatureErr == nil {
+				objects[i] = signature
+			}
+		}
+	}
+	sort.Strings(objects)
+	return objects
+}
+
+// The ID reads e.g. role:analyst/table:sales/orders;returns, objects being
+// separated by ; as function signatures already contain commas.
+func redshiftGrantId(target redshiftGrantTarget, grantee redshiftGrantee) string {
+	parts := []string{
+		fmt.Sprintf("%s:%s", grantee.granteeType, grantee.name),
+		fmt.Sprintf("%s:%s%s", target.objectType, target.database, target.schema),
+	}
+	if len(target.objects) > 0 {
+		parts = append(parts, strings.Join(target.objects, ";"))
+	}
+	return strings.Join(parts, "/")
+}
+
+// Imports a grant by its ID, naming the database for database grants and the
+// schema otherwise, e.g. group:etl/function:udfs/f_add(integer, integer).
+func resourceRedshiftGrantImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client)
+	importId := d.Id()
+
+	parts := strings.SplitN(importId, "/", 3)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("Cannot import %s: expected ID in the form grantee_type:grantee/object_type:schema/objects", importId)
+	}
+
+	grantee := strings.SplitN(parts[0], ":", 2)
+	target := strings.SplitN(parts[1], ":", 2)
+	if len(grantee) != 2 || len(target) != 2 {
+		return nil, fmt.Errorf("Cannot import %s: expected ID in the form grantee_type:grantee/object_type:schema/objects", importId)
+	}
+
+	objectType, ok := redshiftGrantObjectTypes[target[0]]
+	if !ok {
+		return nil, fmt.Errorf("Cannot import %s: unsupported object type %s", importId, target[0])
+	}
+
+	d.Set("grantee_type", grantee[0])
+	d.Set("grantee", grantee[1])
+	d.Set("object_type", target[0])
+	if target[0] == "database" {
+		d.Set("database", target[1])
+	} else if objectType.requiresSchema {
+		d.Set("schema", target[1])
+	}
+	if len(parts) == 3 {
+		d.Set("objects", strings.Split(parts[2], ";"))
+	}
+	d.Set("cascade", false)
+
+	if readErr := redshiftGrantRead(client, d); readErr != nil {
+		return nil, readErr
+	}
+
+	if d.Id() == "" || d.Get("privileges").(*schema.Set).Len() == 0 {
+		return nil, fmt.Errorf("Cannot import %s: grant does not exist", importId)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func redshiftGrantRead(client *Client, d *schema.ResourceData) error {
+	target, grantee := redshiftGrantTargetAndGrantee(d)
+
+	privileges, selectErr := redshiftReadGrantedPrivileges(client, target, grantee)
+	if selectErr != nil {
+		log.Println("error | redshiftGrantRead | selectErr |", selectErr)
+		return selectErr
+	}
+
+	granted := false
+	for _, objectPrivileges := range privileges {
+		if len(objectPrivileges) > 0 {
+			granted = true
+		}
+	}
+
+	// a grant on all tables or functions of an empty schema has nothing to check
+	if len(privileges) == 0 && len(target.objects) == 0 && redshiftGrantObjectTypes[target.objectType].allKeyword != "" {
+		return nil
+	}
+
+	if !granted {
+		log.Println("info | redshiftGrantRead | grant not found |", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("privileges", redshiftCommonPrivileges(privileges))
+
+	return nil
+}
@@ -0,0 +1,310 @@
+package redshift
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+
+func resourceRedshiftGrantColumns() *schema.Resource {
+	return &schema.Resource {
+		Create:        resourceRedshiftGrantColumnsCreate,
+		Read:          resourceRedshiftGrantColumnsRead,
+		Update:        resourceRedshiftGrantColumnsUpdate,
+		Delete:        resourceRedshiftGrantColumnsDelete,
+		CustomizeDiff: resourceRedshiftGrantColumnsCustomizeDiff,
+		Schema: map[string]*schema.Schema {
+			"schema": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"table": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"grantee_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"user", "group", "role"}, false),
+			},
+			"grantee": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"privilege": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource {
+					Schema: map[string]*schema.Schema {
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"select", "update"}, false),
+						},
+						"columns": {
+							Type:     schema.TypeSet,
+							Elem:     &schema.Schema { Type: schema.TypeString },
+							Required: true,
+							MinItems: 1,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceRedshiftGrantColumnsCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	grantee := redshiftGrantColumnsGrantee(d)
+	privileges := redshiftGrantColumnsPrivileges(d.Get("privilege"))
+
+	tx, txBeginErr := client.db.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftGrantColumnsCreate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	for _, statement := range redshiftGrantColumnsStatements(d, "GRANT", privileges, grantee) {
+		if _, grantErr := tx.Exec(statement); grantErr != nil {
+			log.Println("error | resourceRedshiftGrantColumnsCreate | grantErr |", grantErr)
+			tx.Rollback()
+			return grantErr
+		}
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftGrantColumnsCreate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	id := fmt.Sprintf("%s:%s/%s.%s", grantee.granteeType, grantee.name, d.Get("schema"), d.Get("table"))
+	d.SetId(id)
+	return redshiftGrantColumnsRead(client, d)
+}
+
+func resourceRedshiftGrantColumnsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	return redshiftGrantColumnsRead(client, d)
+}
+
+func resourceRedshiftGrantColumnsUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	grantee := redshiftGrantColumnsGrantee(d)
+
+	o, n := d.GetChange("privilege")
+	oldPrivileges := redshiftGrantColumnsPrivileges(o)
+	newPrivileges := redshiftGrantColumnsPrivileges(n)
+
+	grants := make(map[string][]string)
+	revokes := make(map[string][]string)
+	for privilege, columns := range newPrivileges {
+		for _, column := range columns {
+			if !stringInList(column, oldPrivileges[privilege]) {
+				grants[privilege] = append(grants[privilege], column)
+			}
+		}
+	}
+	for privilege, columns := range oldPrivileges {
+		for _, column := range columns {
+			if !stringInList(column, newPrivileges[privilege]) {
+				revokes[privilege] = append(revokes[privilege], column)
+			}
+		}
+	}
+
+	tx, txBeginErr := client.db.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftGrantColumnsUpdate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	statements := append(redshiftGrantColumnsStatements(d, "REVOKE", revokes, grantee), redshiftGrantColumnsStatements(d, "GRANT", grants, grantee)...)
+	for _, statement := range statements {
+		if _, grantErr := tx.Exec(statement); grantErr != nil {
+			log.Println("error | resourceRedshiftGrantColumnsUpdate | grantErr |", grantErr)
+			tx.Rollback()
+			return grantErr
+		}
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftGrantColumnsUpdate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	return redshiftGrantColumnsRead(client, d)
+}
+
+func resourceRedshiftGrantColumnsDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	grantee := redshiftGrantColumnsGrantee(d)
+	privileges := redshiftGrantColumnsPrivileges(d.Get("privilege"))
+
+	tx, txBeginErr := client.db.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftGrantColumnsDelete | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	for _, statement := range redshiftGrantColumnsStatements(d, "REVOKE", privileges, grantee) {
+		if _, revokeErr := tx.Exec(statement); revokeErr != nil {
+			log.Println("error | resourceRedshiftGrantColumnsDelete | revokeErr |", revokeErr)
+			tx.Rollback()
+			return revokeErr
+		}
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftGrantColumnsDelete | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	return nil
+}
+
+func resourceRedshiftGrantColumnsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("privilege") || !d.NewValueKnown("schema") || !d.NewValueKnown("table") {
+		return nil
+	}
+
+	var types []string
+	for _, v := range d.Get("privilege").(*schema.Set).List() {
+		privilegeType := v.(map[string]interface{})["type"].(string)
+		if stringInList(privilegeType, types) {
+			return fmt.Errorf("Privilege %s is listed more than once", privilegeType)
+		}
+		types = append(types, privilegeType)
+	}
+
+	client := meta.(*Client).db
+	selectQuery := fmt.Sprintf(`
+		SELECT
+			a.attname
+		FROM pg_attribute a
+			JOIN pg_class c ON c.oid = a.attrelid
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = '%s'
+			AND c.relname = '%s'
+			AND a.attnum > 0
+			AND NOT a.attisdropped
+	`, d.Get("schema"), d.Get("table"))
+	rows, selectErr := client.Query(selectQuery)
+	if selectErr != nil {
+		log.Println("error | resourceRedshiftGrantColumnsCustomizeDiff | selectErr |", selectErr)
+		return selectErr
+	}
+
+	defer rows.Close()
+	var columns []string
+	for rows.Next() {
+		var column string
+		if selectRowErr := rows.Scan(&column); selectRowErr != nil {
+			return selectRowErr
+		}
+		columns = append(columns, column)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return rowsErr
+	}
+
+	// the table may be created by this very apply, leave it to the grant to fail then
+	if len(columns) == 0 {
+		return nil
+	}
+
+	for privilege, privilegeColumns := range redshiftGrantColumnsPrivileges(d.Get("privilege")) {
+		for _, column := range privilegeColumns {
+			if !stringInList(column, columns) {
+				return fmt.Errorf("Cannot grant %s on %s.%s(%s): column does not exist", privilege, d.Get("schema"), d.Get("table"), column)
+			}
+		}
+	}
+
+	return nil
+}
+
+func redshiftGrantColumnsGrantee(d *schema.ResourceData) redshiftGrantee {
+	return redshiftGrantee {
+		granteeType: d.Get("grantee_type").(string),
+		name:        d.Get("grantee").(string),
+	}
+}
+
+// The privilege blocks as the sorted columns held per privilege.
+func redshiftGrantColumnsPrivileges(v interface{}) map[string][]string {
+	privileges := make(map[string][]string)
+	for _, block := range v.(*schema.Set).List() {
+		privilege := block.(map[string]interface{})
+		columns := usersSetToList(privilege["columns"])
+		sort.Strings(columns)
+		privileges[privilege["type"].(string)] = columns
+	}
+	return privileges
+}
+
+func redshiftGrantColumnsStatements(d *schema.ResourceData, action string, privileges map[string][]string, grantee redshiftGrantee) []string {
+	direction := "TO"
+	if action == "REVOKE" {
+		direction = "FROM"
+	}
+
+	var types []string
+	for privilege := range privileges {
+		types = append(types, privilege)
+	}
+	sort.Strings(types)
+
+	var statements []string
+	for _, privilege := range types {
+		if len(privileges[privilege]) == 0 {
+			continue
+		}
+		statement := fmt.Sprintf("%s %s (%s) ON %s.%s %s %s", action, strings.ToUpper(privilege), strings.Join(privileges[privilege], ", "), d.Get("schema"), d.Get("table"), direction, grantee.sql())
+		statements = append(statements, statement)
+	}
+	return statements
+}
+
+func redshiftGrantColumnsRead(client *Client, d *schema.ResourceData) error {
+	grantee := redshiftGrantColumnsGrantee(d)
+
+	privileges, selectErr := redshiftReadColumnPrivileges(client, d.Get("schema").(string), d.Get("table").(string), grantee)
+	if selectErr != nil {
+		log.Println("error | redshiftGrantColumnsRead | selectErr |", selectErr)
+		return selectErr
+	}
+
+	var blocks []map[string]interface{}
+	for privilege, columns := range privileges {
+		if privilege != "select" && privilege != "update" {
+			continue
+		}
+		sort.Strings(columns)
+		blocks = append(blocks, map[string]interface{} {
+			"type":    privilege,
+			"columns": columns,
+		})
+	}
+
+	if len(blocks) == 0 {
+		log.Println("info | redshiftGrantColumnsRead | grant not found |", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("privilege", blocks)
+
+	return nil
+}
@@ -0,0 +1,291 @@
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+
+func resourceRedshiftGrantDatabase() *schema.Resource {
+	return &schema.Resource {
+		Create:        resourceRedshiftGrantDatabaseCreate,
+		Read:          resourceRedshiftGrantDatabaseRead,
+		Update:        resourceRedshiftGrantDatabaseUpdate,
+		Delete:        resourceRedshiftGrantDatabaseDelete,
+		CustomizeDiff: resourceRedshiftGrantDatabaseCustomizeDiff,
+		Importer: &schema.ResourceImporter {
+			State: resourceRedshiftGrantDatabaseImport,
+		},
+		Schema: map[string]*schema.Schema {
+			"grantee_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"user", "group", "role", "public"}, false),
+			},
+			"grantee": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "name of the user, group or role, omitted for public",
+			},
+			"database": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "database to grant on, defaults to the provider's database",
+			},
+			"create": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"temporary": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"cascade": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "revoke with CASCADE, also stripping privileges granted onwards through grant options",
+			},
+		},
+	}
+}
+
+func resourceRedshiftGrantDatabaseCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	if _, ok := d.GetOk("database"); !ok {
+		var database string
+		if selectErr := client.db.QueryRow("SELECT current_database()").Scan(&database); selectErr != nil {
+			log.Println("error | resourceRedshiftGrantDatabaseCreate | selectErr |", selectErr)
+			return selectErr
+		}
+		d.Set("database", database)
+	}
+
+	target, grantee := redshiftGrantDatabaseTargetAndGrantee(d)
+
+	var grants []string
+	if v, ok := d.GetOk("create"); ok && v.(bool) {
+		grants = append(grants, "create")
+	}
+	if v, ok := d.GetOk("temporary"); ok && v.(bool) {
+		grants = append(grants, "temporary")
+	}
+
+	tx, txBeginErr := client.db.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftGrantDatabaseCreate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	revokeStatement := redshiftRevokeStatement(redshiftGrantObjectTypes["database"].privileges, target, grantee, d.Get("cascade").(bool))
+	if _, revokeErr := tx.Exec(revokeStatement); revokeErr != nil {
+		log.Println("error | resourceRedshiftGrantDatabaseCreate | revokeErr |", revokeErr)
+		tx.Rollback()
+		return revokeErr
+	}
+
+	// a grant without privileges keeps the grantee, typically PUBLIC, off the database
+	if len(grants) > 0 {
+		grantStatement := redshiftGrantStatement(grants, target, grantee)
+		if _, grantErr := tx.Exec(grantStatement); grantErr != nil {
+			log.Println("error | resourceRedshiftGrantDatabaseCreate | grantErr |", grantErr)
+			tx.Rollback()
+			return grantErr
+		}
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftGrantDatabaseCreate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	d.SetId(redshiftGrantDatabaseId(target, grantee))
+	return redshiftGrantDatabaseRead(client, d)
+}
+
+func resourceRedshiftGrantDatabaseRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	return redshiftGrantDatabaseRead(client, d)
+}
+
+func resourceRedshiftGrantDatabaseUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	target, grantee := redshiftGrantDatabaseTargetAndGrantee(d)
+	grants, revokes := redshiftChangedPrivileges(d, []string{"create", "temporary"})
+
+	tx, txBeginErr := client.db.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftGrantDatabaseUpdate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	if len(revokes) > 0 {
+		revokeStatement := redshiftRevokeStatement(revokes, target, grantee, d.Get("cascade").(bool))
+		if _, revokeErr := tx.Exec(revokeStatement); revokeErr != nil {
+			log.Println("error | resourceRedshiftGrantDatabaseUpdate | revokeErr |", revokeErr)
+			tx.Rollback()
+			return revokeErr
+		}
+	}
+
+	if len(grants) > 0 {
+		grantStatement := redshiftGrantStatement(grants, target, grantee)
+		if _, grantErr := tx.Exec(grantStatement); grantErr != nil {
+			log.Println("error | resourceRedshiftGrantDatabaseUpdate | grantErr |", grantErr)
+			tx.Rollback()
+			return grantErr
+		}
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftGrantDatabaseUpdate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	return redshiftGrantDatabaseRead(client, d)
+}
+
+func resourceRedshiftGrantDatabaseDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	target, grantee := redshiftGrantDatabaseTargetAndGrantee(d)
+
+	tx, txBeginErr := client.db.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftGrantDatabaseDelete | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	revokeStatement := redshiftRevokeStatement(redshiftGrantObjectTypes["database"].privileges, target, grantee, d.Get("cascade").(bool))
+	if _, revokeErr := tx.Exec(revokeStatement); revokeErr != nil {
+		log.Println("error | resourceRedshiftGrantDatabaseDelete | revokeErr |", revokeErr)
+		tx.Rollback()
+		return revokeErr
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftGrantDatabaseDelete | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	return nil
+}
+
+func resourceRedshiftGrantDatabaseCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("grantee") {
+		return nil
+	}
+
+	granteeType := d.Get("grantee_type").(string)
+	if grantee := d.Get("grantee").(string); granteeType == "public" && grantee != "" {
+		return fmt.Errorf("grantee must be omitted when grantee_type is public")
+	} else if granteeType != "public" && grantee == "" {
+		return fmt.Errorf("grantee is required when grantee_type is %s", granteeType)
+	}
+
+	return nil
+}
+
+// Database grants are imported by IDs such as group:platform/database:analytics,
+// or public/database:analytics for PUBLIC.
+func resourceRedshiftGrantDatabaseImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client)
+	importId := d.Id()
+
+	parts := strings.SplitN(importId, "/", 2)
+	if len(parts) != 2 || !strings.HasPrefix(parts[1], "database:") {
+		return nil, fmt.Errorf("Cannot import %s: expected ID in the form grantee_type:grantee/database:name", importId)
+	}
+
+	if parts[0] == "public" {
+		d.Set("grantee_type", "public")
+	} else {
+		grantee := strings.SplitN(parts[0], ":", 2)
+		if len(grantee) != 2 || !stringInList(grantee[0], []string{"user", "group", "role"}) || grantee[1] == "" {
+			return nil, fmt.Errorf("Cannot import %s: expected ID in the form grantee_type:grantee/database:name", importId)
+		}
+		d.Set("grantee_type", grantee[0])
+		d.Set("grantee", grantee[1])
+	}
+	d.Set("database", strings.TrimPrefix(parts[1], "database:"))
+	d.Set("cascade", false)
+
+	if readErr := redshiftGrantDatabaseRead(client, d); readErr != nil {
+		return nil, readErr
+	}
+
+	if d.Id() == "" || (!d.Get("create").(bool) && !d.Get("temporary").(bool)) {
+		return nil, fmt.Errorf("Cannot import %s: grant does not exist", importId)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func redshiftGrantDatabaseTargetAndGrantee(d *schema.ResourceData) (redshiftGrantTarget, redshiftGrantee) {
+	target := redshiftGrantTarget {
+		objectType: "database",
+		database:   d.Get("database").(string),
+	}
+	grantee := redshiftGrantee {
+		granteeType: d.Get("grantee_type").(string),
+		name:        d.Get("grantee").(string),
+	}
+	return target, grantee
+}
+
+func redshiftGrantDatabaseId(target redshiftGrantTarget, grantee redshiftGrantee) string {
+	if grantee.granteeType == "public" {
+		return fmt.Sprintf("public/database:%s", target.database)
+	}
+	return fmt.Sprintf("%s:%s/database:%s", grantee.granteeType, grantee.name, target.database)
+}
+
+func redshiftGrantDatabaseRead(client *Client, d *schema.ResourceData) error {
+	target, grantee := redshiftGrantDatabaseTargetAndGrantee(d)
+
+	// a dropped grantee holds nothing, which would otherwise read as a grant of no privileges
+	if grantee.granteeType != "public" {
+		var granteeId string
+		granteeQuery := fmt.Sprintf(redshiftImportLookupQueries[grantee.granteeType], grantee.name)
+		if granteeErr := client.db.QueryRow(granteeQuery).Scan(&granteeId); granteeErr == sql.ErrNoRows {
+			log.Println("info | redshiftGrantDatabaseRead | grantee not found |", grantee.granteeType, grantee.name)
+			d.SetId("")
+			return nil
+		} else if granteeErr != nil {
+			log.Println("error | redshiftGrantDatabaseRead | granteeErr |", granteeErr)
+			return granteeErr
+		}
+	}
+
+	granted, selectErr := redshiftReadGrantedPrivileges(client, target, grantee)
+	if selectErr != nil {
+		log.Println("error | redshiftGrantDatabaseRead | selectErr |", selectErr)
+		return selectErr
+	}
+
+	privileges, ok := granted[target.database]
+	if !ok {
+		log.Println("info | redshiftGrantDatabaseRead | database not found |", target.database)
+		d.SetId("")
+		return nil
+	}
+
+	// the grant owns all of the grantee's database privileges, so holding none is
+	// still a valid state rather than a missing grant
+	d.Set("create", stringInList("create", privileges))
+	d.Set("temporary", stringInList("temporary", privileges))
+
+	return nil
+}
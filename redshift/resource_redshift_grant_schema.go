@@ -0,0 +1,241 @@
+package redshift
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+
+func resourceRedshiftGrantSchemaGroup() *schema.Resource {
+	return redshiftGrantSchemaResource("group")
+}
+
+func resourceRedshiftGrantSchemaUser() *schema.Resource {
+	return redshiftGrantSchemaResource("user")
+}
+
+func resourceRedshiftGrantSchemaRole() *schema.Resource {
+	return redshiftGrantSchemaResource("role")
+}
+
+// A grant of schema privileges to a single group, user or role, named by the
+// attribute of the same name as the grantee type.
+func redshiftGrantSchemaResource(granteeType string) *schema.Resource {
+	read := func(client *Client, d *schema.ResourceData) error {
+		return redshiftGrantSchemaRead(client, d, granteeType)
+	}
+
+	return &schema.Resource {
+		Create: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceRedshiftGrantSchemaCreate(d, meta, granteeType)
+		},
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			return read(meta.(*Client), d)
+		},
+		Update: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceRedshiftGrantSchemaUpdate(d, meta, granteeType)
+		},
+		Delete: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceRedshiftGrantSchemaDelete(d, meta, granteeType)
+		},
+		Importer: &schema.ResourceImporter {
+			State: redshiftGrantImporter([]string{granteeType, "schema"}, read),
+		},
+		Schema: map[string]*schema.Schema {
+			granteeType: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"schema": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"usage": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"create": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"cascade": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "revoke with CASCADE, also stripping privileges granted onwards through grant options",
+			},
+		},
+	}
+}
+
+func resourceRedshiftGrantSchemaCreate(d *schema.ResourceData, meta interface{}, granteeType string) error {
+	client := meta.(*Client)
+	grantee := redshiftResourceGrantee(d, granteeType)
+	target := redshiftGrantSchemaTarget(d)
+
+	var grants []string
+	for _, privilege := range []string{"usage", "create"} {
+		if d.Get(privilege).(bool) {
+			grants = append(grants, privilege)
+		}
+	}
+
+	if len(grants) == 0 {
+		log.Println("error | resourceRedshiftGrantSchemaCreate | len(grants) == 0 | Must have at least 1 privilege")
+		return fmt.Errorf("Must have at least 1 privilege")
+	}
+
+	tx, txBeginErr := client.db.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftGrantSchemaCreate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	statements := []string{
+		redshiftRevokeStatement([]string{"all"}, target, grantee, d.Get("cascade").(bool)),
+		redshiftGrantStatement(grants, target, grantee),
+	}
+	for _, statement := range statements {
+		if _, grantErr := tx.Exec(statement); grantErr != nil {
+			log.Println("error | resourceRedshiftGrantSchemaCreate | grantErr |", statement, "|", grantErr)
+			tx.Rollback()
+			return grantErr
+		}
+	}
+
+	id, idErr := redshiftGrantResourceId(tx, []string{granteeType, "schema"}, []string{grantee.name, target.schema})
+	if idErr != nil {
+		log.Println("error | resourceRedshiftGrantSchemaCreate | idErr |", idErr)
+		tx.Rollback()
+		return idErr
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftGrantSchemaCreate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	d.SetId(id)
+	return redshiftGrantSchemaRead(client, d, granteeType)
+}
+
+func resourceRedshiftGrantSchemaUpdate(d *schema.ResourceData, meta interface{}, granteeType string) error {
+	client := meta.(*Client)
+	grantee := redshiftResourceGrantee(d, granteeType)
+	target := redshiftGrantSchemaTarget(d)
+
+	if !d.Get("usage").(bool) && !d.Get("create").(bool) {
+		log.Println("error | resourceRedshiftGrantSchemaUpdate | len(grants) == 0 | Must have at least 1 privilege")
+		return fmt.Errorf("Must have at least 1 privilege")
+	}
+
+	grants, revokes := redshiftChangedPrivileges(d, []string{"usage", "create"})
+
+	var statements []string
+	if len(revokes) > 0 {
+		statements = append(statements, redshiftRevokeStatement(revokes, target, grantee, d.Get("cascade").(bool)))
+	}
+	if len(grants) > 0 {
+		statements = append(statements, redshiftGrantStatement(grants, target, grantee))
+	}
+
+	tx, txBeginErr := client.db.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftGrantSchemaUpdate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	for _, statement := range statements {
+		if _, grantErr := tx.Exec(statement); grantErr != nil {
+			log.Println("error | resourceRedshiftGrantSchemaUpdate | grantErr |", statement, "|", grantErr)
+			tx.Rollback()
+			return grantErr
+		}
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftGrantSchemaUpdate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	return redshiftGrantSchemaRead(client, d, granteeType)
+}
+
+func resourceRedshiftGrantSchemaDelete(d *schema.ResourceData, meta interface{}, granteeType string) error {
+	client := meta.(*Client)
+	grantee := redshiftResourceGrantee(d, granteeType)
+	target := redshiftGrantSchemaTarget(d)
+
+	tx, txBeginErr := client.db.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftGrantSchemaDelete | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	revokeStatement := redshiftRevokeStatement([]string{"all"}, target, grantee, d.Get("cascade").(bool))
+	if _, revokeErr := tx.Exec(revokeStatement); revokeErr != nil {
+		log.Println("error | resourceRedshiftGrantSchemaDelete | revokeErr |", revokeErr)
+		tx.Rollback()
+		return revokeErr
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftGrantSchemaDelete | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	return nil
+}
+
+func redshiftGrantSchemaTarget(d *schema.ResourceData) redshiftGrantTarget {
+	return redshiftGrantTarget {
+		objectType: "schema",
+		schema:     d.Get("schema").(string),
+	}
+}
+
+func redshiftGrantSchemaRead(client *Client, d *schema.ResourceData, granteeType string) error {
+	names, namesErr := redshiftGrantResourceNames(client.db, []string{granteeType, "schema"}, strings.SplitN(d.Id(), "-", 2))
+	if namesErr != nil {
+		log.Println("error | redshiftGrantSchemaRead | namesErr |", namesErr)
+		return namesErr
+	}
+	if names == nil {
+		d.SetId("")
+		return nil
+	}
+
+	grantee := redshiftGrantee {
+		granteeType: granteeType,
+		name:        names[0],
+	}
+	target := redshiftGrantTarget {
+		objectType: "schema",
+		schema:     names[1],
+	}
+	granted, privilegesErr := redshiftReadGrantedPrivileges(client, target, grantee)
+	if privilegesErr != nil {
+		log.Println("error | redshiftGrantSchemaRead | privilegesErr |", privilegesErr)
+		return privilegesErr
+	}
+
+	privileges := granted[target.schema]
+	if len(privileges) == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(granteeType, grantee.name)
+	d.Set("schema", target.schema)
+	d.Set("usage", stringInList("usage", privileges))
+	d.Set("create", stringInList("create", privileges))
+
+	return nil
+}
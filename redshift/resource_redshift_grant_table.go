@@ -0,0 +1,536 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var redshiftGrantTablePrivileges = []string{"select", "insert", "update", "delete", "references"}
+
+
+func resourceRedshiftGrantTableGroup() *schema.Resource {
+	return redshiftGrantTableResource("group")
+}
+
+func resourceRedshiftGrantTableUser() *schema.Resource {
+	return redshiftGrantTableResource("user")
+}
+
+func resourceRedshiftGrantTableRole() *schema.Resource {
+	return redshiftGrantTableResource("role")
+}
+
+// A grant of table privileges to a single group, user or role, named by the
+// attribute of the same name as the grantee type. With an owner the grant
+// covers every table of the schema, including those the owner creates later.
+func redshiftGrantTableResource(granteeType string) *schema.Resource {
+	read := func(client *Client, d *schema.ResourceData) error {
+		return redshiftGrantTableRead(client, d, granteeType)
+	}
+
+	return &schema.Resource {
+		Create: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceRedshiftGrantTableCreate(d, meta, granteeType)
+		},
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			return read(meta.(*Client), d)
+		},
+		Update: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceRedshiftGrantTableUpdate(d, meta, granteeType)
+		},
+		Delete: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceRedshiftGrantTableDelete(d, meta, granteeType)
+		},
+		Importer: &schema.ResourceImporter {
+			State: redshiftGrantTableImporter(granteeType, read),
+		},
+		Schema: map[string]*schema.Schema {
+			granteeType: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"schema": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"owner": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"owner", "tables", "table_pattern"},
+				Description:  "grant on every table of the schema, including those the owner creates later",
+			},
+			"tables": {
+				Type:         schema.TypeSet,
+				Elem:         &schema.Schema { Type: schema.TypeString },
+				Optional:     true,
+				ExactlyOneOf: []string{"owner", "tables", "table_pattern"},
+				Description:  "grant on these tables and views only",
+			},
+			"table_pattern": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"owner", "tables", "table_pattern"},
+				ValidateFunc: validation.All(validation.StringIsNotEmpty, validation.StringIsValidRegExp),
+				Description:  "grant on the tables and views whose name matches this regular expression, tables matching later are granted on at the next update",
+			},
+			"select": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"insert": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"references": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"cascade": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "revoke with CASCADE, also stripping privileges granted onwards through grant options",
+			},
+			"granted_tables": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema { Type: schema.TypeString },
+				Computed:    true,
+				Description: "tables the privileges were granted on, revoked from again when they are no longer covered",
+			},
+			"non_compliant_tables": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema { Type: schema.TypeString },
+				Computed:    true,
+				Description: "tables covered by the grant missing any of the granted privileges",
+			},
+		},
+	}
+}
+
+func resourceRedshiftGrantTableCreate(d *schema.ResourceData, meta interface{}, granteeType string) error {
+	client := meta.(*Client)
+	grantee := redshiftResourceGrantee(d, granteeType)
+	schemaName := d.Get("schema").(string)
+	owner := d.Get("owner").(string)
+
+	var grants []string
+	for _, privilege := range redshiftGrantTablePrivileges {
+		if d.Get(privilege).(bool) {
+			grants = append(grants, privilege)
+		}
+	}
+
+	if len(grants) == 0 {
+		log.Println("error | resourceRedshiftGrantTableCreate | len(grants) == 0 | Must have at least 1 privilege")
+		return fmt.Errorf("Must have at least 1 privilege")
+	}
+
+	tx, txBeginErr := client.db.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftGrantTableCreate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	statements, tables, statementsErr := redshiftGrantTableStatements(tx, d, grantee, []string{"all"}, grants)
+	if statementsErr != nil {
+		log.Println("error | resourceRedshiftGrantTableCreate | statementsErr |", statementsErr)
+		tx.Rollback()
+		return statementsErr
+	}
+
+	for _, statement := range statements {
+		if _, grantErr := tx.Exec(statement); grantErr != nil {
+			log.Println("error | resourceRedshiftGrantTableCreate | grantErr |", statement, "|", grantErr)
+			tx.Rollback()
+			return grantErr
+		}
+	}
+
+	kinds := []string{granteeType, "schema"}
+	names := []string{grantee.name, schemaName}
+	if owner != "" {
+		kinds = append(kinds, "owner")
+		names = append(names, owner)
+	}
+	id, idErr := redshiftGrantResourceId(tx, kinds, names)
+	if idErr != nil {
+		log.Println("error | resourceRedshiftGrantTableCreate | idErr |", idErr)
+		tx.Rollback()
+		return idErr
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftGrantTableCreate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	d.SetId(id)
+	d.Set("granted_tables", tables)
+	return redshiftGrantTableRead(client, d, granteeType)
+}
+
+func resourceRedshiftGrantTableUpdate(d *schema.ResourceData, meta interface{}, granteeType string) error {
+	client := meta.(*Client)
+	grantee := redshiftResourceGrantee(d, granteeType)
+
+	granted := false
+	for _, privilege := range redshiftGrantTablePrivileges {
+		granted = granted || d.Get(privilege).(bool)
+	}
+	if !granted {
+		log.Println("error | resourceRedshiftGrantTableUpdate | len(grants) == 0 | Must have at least 1 privilege")
+		return fmt.Errorf("Must have at least 1 privilege")
+	}
+
+	grants, revokes := redshiftChangedPrivileges(d, redshiftGrantTablePrivileges)
+
+	tx, txBeginErr := client.db.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftGrantTableUpdate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	statements, tables, statementsErr := redshiftGrantTableStatements(tx, d, grantee, revokes, grants)
+	if statementsErr != nil {
+		log.Println("error | resourceRedshiftGrantTableUpdate | statementsErr |", statementsErr)
+		tx.Rollback()
+		return statementsErr
+	}
+
+	for _, statement := range statements {
+		if _, grantErr := tx.Exec(statement); grantErr != nil {
+			log.Println("error | resourceRedshiftGrantTableUpdate | grantErr |", statement, "|", grantErr)
+			tx.Rollback()
+			return grantErr
+		}
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftGrantTableUpdate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	d.Set("granted_tables", tables)
+	return redshiftGrantTableRead(client, d, granteeType)
+}
+
+func resourceRedshiftGrantTableDelete(d *schema.ResourceData, meta interface{}, granteeType string) error {
+	client := meta.(*Client)
+	grantee := redshiftResourceGrantee(d, granteeType)
+
+	tx, txBeginErr := client.db.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftGrantTableDelete | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	var statements []string
+	if d.Get("owner").(string) != "" {
+		var statementsErr error
+		if statements, _, statementsErr = redshiftGrantTableStatements(tx, d, grantee, []string{"all"}, nil); statementsErr != nil {
+			log.Println("error | resourceRedshiftGrantTableDelete | statementsErr |", statementsErr)
+			tx.Rollback()
+			return statementsErr
+		}
+	} else {
+		tables, tablesErr := redshiftGrantedTables(tx, d)
+		if tablesErr != nil {
+			log.Println("error | resourceRedshiftGrantTableDelete | tablesErr |", tablesErr)
+			tx.Rollback()
+			return tablesErr
+		}
+
+		// without tables the target would cover the whole schema
+		if len(tables) > 0 {
+			target := redshiftGrantTarget {
+				objectType: "table",
+				schema:     d.Get("schema").(string),
+				objects:    tables,
+			}
+			statements = append(statements, redshiftRevokeStatement([]string{"all"}, target, grantee, d.Get("cascade").(bool)))
+		}
+	}
+
+	for _, statement := range statements {
+		if _, revokeErr := tx.Exec(statement); revokeErr != nil {
+			log.Println("error | resourceRedshiftGrantTableDelete | revokeErr |", statement, "|", revokeErr)
+			tx.Rollback()
+			return revokeErr
+		}
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftGrantTableDelete | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	return nil
+}
+
+// The statements revoking and then granting privileges. With an owner they
+// cover every table of the schema and its default privileges, otherwise they
+// move the grant from the tables granted on so far to those it covers now: the
+// tables still covered only get the privileges that changed, the tables newly
+// covered get every privilege of the grant and the tables no longer covered
+// lose them all. The tables covered now are returned alongside.
+func redshiftGrantTableStatements(tx *sql.Tx, d *schema.ResourceData, grantee redshiftGrantee, revokes []string, grants []string) ([]string, []string, error) {
+	target := redshiftGrantTarget {
+		objectType: "table",
+		schema:     d.Get("schema").(string),
+	}
+	cascade := d.Get("cascade").(bool)
+
+	var statements []string
+	if owner := d.Get("owner").(string); owner != "" {
+		if len(revokes) > 0 {
+			statements = append(statements,
+				redshiftRevokeStatement(revokes, target, grantee, cascade),
+				redshiftDefaultPrivilegesStatement("REVOKE", revokes, owner, target.schema, "tables", grantee),
+			)
+		}
+		if len(grants) > 0 {
+			statements = append(statements,
+				redshiftGrantStatement(grants, target, grantee),
+				redshiftDefaultPrivilegesStatement("GRANT", grants, owner, target.schema, "tables", grantee),
+			)
+		}
+		return statements, nil, nil
+	}
+
+	tables, tablesErr := redshiftGrantTableTargets(tx, d)
+	if tablesErr != nil {
+		return nil, nil, tablesErr
+	}
+
+	if len(tables) == 0 && d.Get("tables").(*schema.Set).Len() > 0 {
+		return nil, nil, fmt.Errorf("None of the tables exist in schema %s", target.schema)
+	}
+
+	granted := []string{}
+	if d.Id() != "" {
+		var grantedErr error
+		if granted, grantedErr = redshiftGrantedTables(tx, d); grantedErr != nil {
+			return nil, nil, grantedErr
+		}
+	}
+
+	var privileges []string
+	for _, privilege := range redshiftGrantTablePrivileges {
+		if d.Get(privilege).(bool) {
+			privileges = append(privileges, privilege)
+		}
+	}
+
+	var removed, kept, added []string
+	for _, table := range granted {
+		if !stringInList(table, tables) {
+			removed = append(removed, table)
+		}
+	}
+	for _, table := range tables {
+		if stringInList(table, granted) {
+			kept = append(kept, table)
+		} else {
+			added = append(added, table)
+		}
+	}
+
+	// without tables a target would cover the whole schema
+	if len(removed) > 0 {
+		removedTarget := target
+		removedTarget.objects = removed
+		statements = append(statements, redshiftRevokeStatement([]string{"all"}, removedTarget, grantee, cascade))
+	}
+	if len(kept) > 0 {
+		keptTarget := target
+		keptTarget.objects = kept
+		if len(revokes) > 0 {
+			statements = append(statements, redshiftRevokeStatement(revokes, keptTarget, grantee, cascade))
+		}
+		if len(grants) > 0 {
+			statements = append(statements, redshiftGrantStatement(grants, keptTarget, grantee))
+		}
+	}
+	if len(added) > 0 {
+		addedTarget := target
+		addedTarget.objects = added
+		statements = append(statements, redshiftRevokeStatement([]string{"all"}, addedTarget, grantee, cascade))
+		if len(privileges) > 0 {
+			statements = append(statements, redshiftGrantStatement(privileges, addedTarget, grantee))
+		}
+	}
+
+	return statements, tables, nil
+}
+
+// The tables a grant without an owner was made on that still exist. Grants
+// recorded before granted_tables was kept fall back to the tables covered now.
+func redshiftGrantedTables(client interface{ Query(string, ...interface{}) (*sql.Rows, error) }, d *schema.ResourceData) ([]string, error) {
+	granted := usersSetToList(d.Get("granted_tables"))
+	if len(granted) == 0 {
+		return redshiftGrantTableTargets(client, d)
+	}
+	return redshiftSchemaTables(client, d.Get("schema").(string), granted)
+}
+
+// Imports a grant on every table of the schema by group:analysts/schema:sales/owner:etl,
+// or on specific tables by group:analysts/schema:sales/tables:orders,returns or
+// group:analysts/schema:sales/table_pattern:^orders_.
+func redshiftGrantTableImporter(granteeType string, read func(*Client, *schema.ResourceData) error) schema.StateFunc {
+	ownerImporter := redshiftGrantImporter([]string{granteeType, "schema", "owner"}, read)
+	tablesImporter := redshiftGrantImporter([]string{granteeType, "schema"}, read)
+
+	return func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		// the pattern may itself contain / or :, so only the first two parts are split off
+		parts := strings.SplitN(d.Id(), "/", 3)
+		if len(parts) < 3 {
+			return ownerImporter(d, meta)
+		}
+
+		selector := strings.SplitN(parts[2], ":", 2)
+		switch {
+		case len(selector) == 2 && selector[0] == "tables" && selector[1] != "":
+			d.Set("tables", strings.Split(selector[1], ","))
+		case len(selector) == 2 && selector[0] == "table_pattern" && selector[1] != "":
+			d.Set("table_pattern", selector[1])
+		default:
+			return ownerImporter(d, meta)
+		}
+
+		d.SetId(strings.Join(parts[:2], "/"))
+		return tablesImporter(d, meta)
+	}
+}
+
+func redshiftGrantTableRead(client *Client, d *schema.ResourceData, granteeType string) error {
+	kinds := []string{granteeType, "schema"}
+	ids := strings.SplitN(d.Id(), "-", 3)
+	if len(ids) == 3 {
+		kinds = append(kinds, "owner")
+	}
+
+	names, namesErr := redshiftGrantResourceNames(client.db, kinds, ids)
+	if namesErr != nil {
+		log.Println("error | redshiftGrantTableRead | namesErr |", namesErr)
+		return namesErr
+	}
+	if names == nil {
+		d.SetId("")
+		return nil
+	}
+
+	grantee := redshiftGrantee {
+		granteeType: granteeType,
+		name:        names[0],
+	}
+	target := redshiftGrantTarget {
+		objectType: "table",
+		schema:     names[1],
+	}
+
+	privileges := make(map[string]bool)
+	if len(names) == 3 {
+		owner := names[2]
+		defaultPrivileges, privilegesErr := redshiftReadDefaultPrivileges(client, owner, target.schema, "tables", grantee)
+		if privilegesErr != nil {
+			log.Println("error | redshiftGrantTableRead | privilegesErr |", privilegesErr)
+			return privilegesErr
+		}
+
+		if len(defaultPrivileges) == 0 {
+			d.SetId("")
+			return nil
+		}
+
+		for _, privilege := range redshiftGrantTablePrivileges {
+			privileges[privilege] = stringInList(privilege, defaultPrivileges)
+		}
+		d.Set("owner", owner)
+	} else {
+		tables, tablesErr := redshiftGrantTableTargets(client.db, d)
+		if tablesErr != nil {
+			log.Println("error | redshiftGrantTableRead | tablesErr |", tablesErr)
+			return tablesErr
+		}
+
+		if len(tables) == 0 && d.Get("tables").(*schema.Set).Len() > 0 {
+			log.Println("info | redshiftGrantTableRead | none of the tables exist |", d.Id())
+			d.SetId("")
+			return nil
+		}
+		target.objects = tables
+
+		// dropped tables are forgotten, there is nothing left to revoke from them
+		granted, grantedErr := redshiftGrantedTables(client.db, d)
+		if grantedErr != nil {
+			log.Println("error | redshiftGrantTableRead | grantedErr |", grantedErr)
+			return grantedErr
+		}
+		d.Set("granted_tables", granted)
+
+		// the privileges on specific tables are whatever was granted, less what any of them lacks
+		declared := false
+		for _, privilege := range redshiftGrantTablePrivileges {
+			privileges[privilege] = d.Get(privilege).(bool)
+			declared = declared || privileges[privilege]
+		}
+
+		// nothing is declared yet on import, the grant is then whatever every table holds
+		if !declared && len(tables) > 0 {
+			granted, privilegesErr := redshiftReadGrantedPrivileges(client, target, grantee)
+			if privilegesErr != nil {
+				log.Println("error | redshiftGrantTableRead | privilegesErr |", privilegesErr)
+				return privilegesErr
+			}
+
+			common := redshiftCommonPrivileges(granted)
+			for _, privilege := range redshiftGrantTablePrivileges {
+				privileges[privilege] = stringInList(privilege, common)
+				declared = declared || privileges[privilege]
+			}
+			if !declared {
+				d.SetId("")
+				return nil
+			}
+		}
+	}
+
+	nonCompliantTables := []string{}
+	if len(names) == 3 || len(target.objects) > 0 {
+		var tablesErr error
+		if nonCompliantTables, tablesErr = redshiftNonCompliantTables(client, target, grantee, privileges); tablesErr != nil {
+			log.Println("error | redshiftGrantTableRead | tablesErr |", tablesErr)
+			return tablesErr
+		}
+	}
+
+	d.Set(granteeType, grantee.name)
+	d.Set("schema", target.schema)
+	for privilege, granted := range privileges {
+		d.Set(privilege, granted)
+	}
+	d.Set("non_compliant_tables", nonCompliantTables)
+
+	return nil
+}
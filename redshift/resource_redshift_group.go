@@ -17,7 +17,7 @@ func resourceRedshiftGroup() *schema.Resource {
 		Update: resourceRedshiftGroupUpdate,
 		Delete: resourceRedshiftGroupDelete,
 		Importer: &schema.ResourceImporter {
-			State: schema.ImportStatePassthrough,
+			State: resourceRedshiftGroupImport,
 		},
 		Schema: map[string]*schema.Schema {
 			"name": {
@@ -29,6 +29,18 @@ func resourceRedshiftGroup() *schema.Resource {
 				Elem:     &schema.Schema { Type: schema.TypeString },
 				Optional: true,
 			},
+			"ignore_external_users": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "only track the members listed in users, leaving members added elsewhere (e.g. by redshift_group_membership) alone",
+			},
+			"revoke_all_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "revoke every privilege and default privilege held by the group before dropping it",
+			},
 		},
 	}
 }
@@ -77,6 +89,21 @@ func resourceRedshiftGroupRead(d *schema.ResourceData, meta interface{}) error {
 	return redshiftGroupRead(client, d)
 }
 
+func resourceRedshiftGroupImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client).db
+
+	id, resolveErr := redshiftResolveImportId(client, "group", d.Id())
+	if resolveErr != nil {
+		log.Println("error | resourceRedshiftGroupImport | resolveErr |", resolveErr)
+		return nil, resolveErr
+	}
+
+	d.SetId(id)
+	d.Set("ignore_external_users", false)
+	d.Set("revoke_all_on_destroy", false)
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceRedshiftGroupUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Client).db
 
@@ -99,24 +126,10 @@ func resourceRedshiftGroupUpdate(d *schema.ResourceData, meta interface{}) error
 	if d.HasChange("users") {
 		name := d.Get("name")
 		oldUsersSet, newUsersSet := d.GetChange("users")
-		oldUsers, newUsers := usersSetToList(oldUsersSet), usersSetToList(newUsersSet)
-
-		if len(oldUsers) > 0 {
-			dropUsersStatement := fmt.Sprintf("ALTER GROUP %s DROP USER %s", name, strings.Join(oldUsers, ","))
-			if _, dropUsersErr := tx.Exec(dropUsersStatement); dropUsersErr != nil {
-				log.Println("error | resourceRedshiftGroupUpdate | dropUsersErr |", dropUsersErr)
-				tx.Rollback()
-				return dropUsersErr
-			}
-		}
-
-		if len(newUsers) > 0 {
-			addUsersStatement := fmt.Sprintf("ALTER GROUP %s ADD USER %s", name, strings.Join(newUsers, ","))
-			if _, addUsersErr := tx.Exec(addUsersStatement); addUsersErr != nil {
-				log.Println("error | resourceRedshiftGroupUpdate | addUsersErr |", addUsersErr)
-				tx.Rollback()
-				return addUsersErr
-			}
+		if alterUsersErr := redshiftGroupAlterUsers(tx, name.(string), oldUsersSet, newUsersSet); alterUsersErr != nil {
+			log.Println("error | resourceRedshiftGroupUpdate | alterUsersErr |", alterUsersErr)
+			tx.Rollback()
+			return alterUsersErr
 		}
 	}
 
@@ -138,6 +151,14 @@ func resourceRedshiftGroupDelete(d *schema.ResourceData, meta interface{}) error
 		return txBeginErr
 	}
 
+	if d.Get("revoke_all_on_destroy").(bool) {
+		if revokeErr := redshiftGroupRevokeAll(tx, name.(string)); revokeErr != nil {
+			log.Println("error | resourceRedshiftGroupDelete | revokeErr |", revokeErr)
+			tx.Rollback()
+			return revokeErr
+		}
+	}
+
 	dropStatement := fmt.Sprintf("DROP GROUP %s", name)
 	if _, dropErr := tx.Exec(dropStatement); dropErr != nil {
 		log.Println("error | resourceRedshiftGroupDelete | dropErr |", dropErr)
@@ -170,39 +191,189 @@ func redshiftGroupRead(client *sql.DB, d *schema.ResourceData) error {
 		}
 	}
 
+	members, selectUsersErr := redshiftGroupMembers(client, id)
+	if selectUsersErr != nil {
+		log.Println("error | redshiftGroupRead | selectUsersErr", selectUsersErr)
+		return selectUsersErr
+	}
+
 	var users = []string{}
+	if d.Get("ignore_external_users").(bool) {
+		for _, user := range usersSetToList(d.Get("users")) {
+			if stringInList(user, members) {
+				users = append(users, user)
+			}
+		}
+	} else {
+		users = append(users, members...)
+	}
+
+	d.Set("name", name)
+	d.Set("users", users)
+	return nil
+}
+
+// Only the users that were added or removed are touched, so unchanged members keep
+// their access throughout. Removed users that no longer exist are skipped.
+func redshiftGroupAlterUsers(tx *sql.Tx, group string, oldUsersSet interface{}, newUsersSet interface{}) error {
+	droppedUsers := usersSetToList(oldUsersSet.(*schema.Set).Difference(newUsersSet.(*schema.Set)))
+	addedUsers := usersSetToList(newUsersSet.(*schema.Set).Difference(oldUsersSet.(*schema.Set)))
+
+	droppedUsers, selectUsersErr := redshiftExistingUsers(tx, droppedUsers)
+	if selectUsersErr != nil {
+		log.Println("error | redshiftGroupAlterUsers | selectUsersErr |", selectUsersErr)
+		return selectUsersErr
+	}
+
+	if len(droppedUsers) > 0 {
+		dropUsersStatement := fmt.Sprintf("ALTER GROUP %s DROP USER %s", group, strings.Join(droppedUsers, ","))
+		if _, dropUsersErr := tx.Exec(dropUsersStatement); dropUsersErr != nil {
+			log.Println("error | redshiftGroupAlterUsers | dropUsersErr |", dropUsersErr)
+			return dropUsersErr
+		}
+	}
+
+	if len(addedUsers) > 0 {
+		addUsersStatement := fmt.Sprintf("ALTER GROUP %s ADD USER %s", group, strings.Join(addedUsers, ","))
+		if _, addUsersErr := tx.Exec(addUsersStatement); addUsersErr != nil {
+			log.Println("error | redshiftGroupAlterUsers | addUsersErr |", addUsersErr)
+			return addUsersErr
+		}
+	}
+
+	return nil
+}
+
+func redshiftGroupRevokeAll(tx *sql.Tx, group string) error {
+	grantee := redshiftGrantee {
+		granteeType: "group",
+		name:        group,
+	}
+
+	schemas, selectSchemasErr := redshiftCollectGranted(tx, `
+		SELECT
+			QUOTE_IDENT(nspname),
+			nspacl
+		FROM pg_namespace
+		WHERE nspacl IS NOT NULL
+	`, grantee)
+	if selectSchemasErr != nil {
+		return selectSchemasErr
+	}
+
+	tables, selectTablesErr := redshiftCollectGranted(tx, `
+		SELECT
+			QUOTE_IDENT(n.nspname) + '.' + QUOTE_IDENT(c.relname),
+			c.relacl
+		FROM pg_class c
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN ('r', 'v')
+			AND c.relacl IS NOT NULL
+	`, grantee)
+	if selectTablesErr != nil {
+		return selectTablesErr
+	}
+
+	functions, selectFunctionsErr := redshiftCollectGranted(tx, `
+		SELECT
+			CASE WHEN p.prokind = 'p' THEN 'PROCEDURE ' ELSE 'FUNCTION ' END
+				+ QUOTE_IDENT(n.nspname) + '.' + QUOTE_IDENT(p.proname) + '(' + oidvectortypes(p.proargtypes) + ')',
+			p.proacl
+		FROM pg_proc_info p
+			JOIN pg_namespace n ON n.oid = p.pronamespace
+		WHERE p.proacl IS NOT NULL
+	`, grantee)
+	if selectFunctionsErr != nil {
+		return selectFunctionsErr
+	}
+
+	defaults, selectDefaultsErr := redshiftCollectGranted(tx, `
+		SELECT
+			'FOR USER ' + QUOTE_IDENT(u.usename)
+				+ COALESCE(' IN SCHEMA ' + QUOTE_IDENT(n.nspname), '')
+				+ CASE d.defaclobjtype WHEN 'f' THEN ' REVOKE ALL ON FUNCTIONS' WHEN 'p' THEN ' REVOKE ALL ON PROCEDURES' ELSE ' REVOKE ALL ON TABLES' END,
+			d.defaclacl
+		FROM pg_default_acl d
+			JOIN pg_user u ON u.usesysid = d.defacluser
+			LEFT JOIN pg_namespace n ON n.oid = d.defaclnamespace
+		WHERE d.defaclacl IS NOT NULL
+	`, grantee)
+	if selectDefaultsErr != nil {
+		return selectDefaultsErr
+	}
+
+	var statements []string
+	for _, schema := range schemas {
+		statements = append(statements, fmt.Sprintf("REVOKE ALL ON SCHEMA %s FROM GROUP %s", schema, group))
+	}
+	for _, table := range tables {
+		statements = append(statements, fmt.Sprintf("REVOKE ALL ON %s FROM GROUP %s", table, group))
+	}
+	for _, function := range functions {
+		statements = append(statements, fmt.Sprintf("REVOKE ALL ON %s FROM GROUP %s", function, group))
+	}
+	for _, defaultPrivileges := range defaults {
+		statements = append(statements, fmt.Sprintf("ALTER DEFAULT PRIVILEGES %s FROM GROUP %s", defaultPrivileges, group))
+	}
+
+	for _, statement := range statements {
+		if _, revokeErr := tx.Exec(statement); revokeErr != nil {
+			log.Println("error | redshiftGroupRevokeAll | revokeErr |", statement, "|", revokeErr)
+			return revokeErr
+		}
+	}
+
+	return nil
+}
+
+func redshiftExistingUsers(tx *sql.Tx, users []string) ([]string, error) {
+	var existingUsers []string
+	if len(users) == 0 {
+		return existingUsers, nil
+	}
+
+	selectUsersQuery := fmt.Sprintf("SELECT usename FROM pg_user WHERE usename IN ('%s')", strings.Join(users, "','"))
+	rows, selectUsersErr := tx.Query(selectUsersQuery)
+	if selectUsersErr != nil {
+		return nil, selectUsersErr
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var user string
+		if selectUsersRowErr := rows.Scan(&user); selectUsersRowErr != nil {
+			return nil, selectUsersRowErr
+		}
+		existingUsers = append(existingUsers, user)
+	}
+
+	return existingUsers, rows.Err()
+}
+
+func redshiftGroupMembers(client *sql.DB, groupId string) ([]string, error) {
+	var users []string
 	selectUsersQuery := fmt.Sprintf(`
 		SELECT
 			u.usename
 		FROM pg_group g
 		    JOIN pg_user u ON u.usesysid = ANY(g.grolist)
 		WHERE g.grosysid = %s
-	`, id)
+	`, groupId)
 	rows, selectUsersErr := client.Query(selectUsersQuery)
-
 	if selectUsersErr != nil {
-		log.Println("error | redshiftGroupRead | selectUsersErr", selectUsersErr)
-		if selectUsersErr == sql.ErrNoRows {
-			d.SetId("")
-			return nil
-		} else {
-			return selectUsersErr
-		}
+		return nil, selectUsersErr
 	}
 
 	defer rows.Close()
 	for rows.Next() {
 		var user string
 		if selectUsersRowErr := rows.Scan(&user); selectUsersRowErr != nil {
-			log.Println("error | redshiftGroupRead | selectUsersRowErr |", selectUsersRowErr)
-			return selectUsersRowErr
+			return nil, selectUsersRowErr
 		}
 		users = append(users, user)
 	}
 
-	d.Set("name", name)
-	d.Set("users", users)
-	return nil
+	return users, rows.Err()
 }
 
 func usersSetToList(usersSet interface{}) []string {
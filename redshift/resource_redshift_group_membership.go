@@ -0,0 +1,172 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+
+func resourceRedshiftGroupMembership() *schema.Resource {
+	return &schema.Resource {
+		Create: resourceRedshiftGroupMembershipCreate,
+		Read:   resourceRedshiftGroupMembershipRead,
+		Update: resourceRedshiftGroupMembershipUpdate,
+		Delete: resourceRedshiftGroupMembershipDelete,
+		Schema: map[string]*schema.Schema {
+			"group": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"users": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema { Type: schema.TypeString },
+				Required: true,
+				MinItems: 1,
+			},
+		},
+	}
+}
+
+func resourceRedshiftGroupMembershipCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+	group := d.Get("group")
+	users := usersSetToList(d.Get("users"))
+
+	tx, txBeginErr := client.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftGroupMembershipCreate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	addUsersStatement := fmt.Sprintf("ALTER GROUP %s ADD USER %s", group, strings.Join(users, ","))
+	if _, addUsersErr := tx.Exec(addUsersStatement); addUsersErr != nil {
+		log.Println("error | resourceRedshiftGroupMembershipCreate | addUsersErr |", addUsersErr)
+		tx.Rollback()
+		return addUsersErr
+	}
+
+	var groupId string
+	selectQuery := fmt.Sprintf("SELECT grosysid FROM pg_group WHERE groname = '%s'", group)
+	selectErr := tx.QueryRow(selectQuery).Scan(&groupId)
+	if selectErr != nil {
+		log.Println("error | resourceRedshiftGroupMembershipCreate | selectErr |", selectErr)
+		tx.Rollback()
+		return selectErr
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftGroupMembershipCreate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	id := fmt.Sprintf("%s-%d", groupId, schema.HashString(strings.Join(users, ",")))
+	d.SetId(id)
+	return redshiftGroupMembershipRead(client, d)
+}
+
+func resourceRedshiftGroupMembershipRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+	return redshiftGroupMembershipRead(client, d)
+}
+
+func resourceRedshiftGroupMembershipUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+	group := d.Get("group")
+
+	tx, txBeginErr := client.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftGroupMembershipUpdate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	if d.HasChange("users") {
+		oldUsersSet, newUsersSet := d.GetChange("users")
+		if alterUsersErr := redshiftGroupAlterUsers(tx, group.(string), oldUsersSet, newUsersSet); alterUsersErr != nil {
+			log.Println("error | resourceRedshiftGroupMembershipUpdate | alterUsersErr |", alterUsersErr)
+			tx.Rollback()
+			return alterUsersErr
+		}
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftGroupMembershipUpdate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	return redshiftGroupMembershipRead(client, d)
+}
+
+func resourceRedshiftGroupMembershipDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+	group := d.Get("group")
+	users := usersSetToList(d.Get("users"))
+
+	tx, txBeginErr := client.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftGroupMembershipDelete | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	users, selectUsersErr := redshiftExistingUsers(tx, users)
+	if selectUsersErr != nil {
+		log.Println("error | resourceRedshiftGroupMembershipDelete | selectUsersErr |", selectUsersErr)
+		tx.Rollback()
+		return selectUsersErr
+	}
+
+	if len(users) > 0 {
+		dropUsersStatement := fmt.Sprintf("ALTER GROUP %s DROP USER %s", group, strings.Join(users, ","))
+		if _, dropUsersErr := tx.Exec(dropUsersStatement); dropUsersErr != nil {
+			log.Println("error | resourceRedshiftGroupMembershipDelete | dropUsersErr |", dropUsersErr)
+			tx.Rollback()
+			return dropUsersErr
+		}
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftGroupMembershipDelete | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	return nil
+}
+
+func redshiftGroupMembershipRead(client *sql.DB, d *schema.ResourceData) error {
+	groupId := strings.SplitN(d.Id(), "-", 2)[0]
+
+	var group string
+	selectGroupQuery := fmt.Sprintf("SELECT groname FROM pg_group WHERE grosysid = %s", groupId)
+	selectGroupErr := client.QueryRow(selectGroupQuery).Scan(&group)
+
+	if selectGroupErr != nil {
+		log.Println("error | redshiftGroupMembershipRead | selectGroupErr |", selectGroupErr)
+		if selectGroupErr == sql.ErrNoRows {
+			d.SetId("")
+			return nil
+		} else {
+			return selectGroupErr
+		}
+	}
+
+	members, selectUsersErr := redshiftGroupMembers(client, groupId)
+	if selectUsersErr != nil {
+		log.Println("error | redshiftGroupMembershipRead | selectUsersErr |", selectUsersErr)
+		return selectUsersErr
+	}
+
+	var users = []string{}
+	for _, user := range usersSetToList(d.Get("users")) {
+		if stringInList(user, members) {
+			users = append(users, user)
+		}
+	}
+
+	d.Set("group", group)
+	d.Set("users", users)
+	return nil
+}
@@ -0,0 +1,316 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+
+// A one-shot migration: creating the resource copies each group's members and
+// privileges onto an equivalent role. Privileges granted to the groups later are
+// not copied, the migration only runs again once one of its roles is dropped.
+// Destroying it leaves the roles in place.
+func resourceRedshiftGroupRoleMigration() *schema.Resource {
+	return &schema.Resource {
+		Create: resourceRedshiftGroupRoleMigrationCreate,
+		Read:   resourceRedshiftGroupRoleMigrationRead,
+		Delete: resourceRedshiftGroupRoleMigrationDelete,
+		Schema: map[string]*schema.Schema {
+			"groups": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema { Type: schema.TypeString },
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+			},
+			"role_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "",
+				Description: "prefix added to each group name to build the role name",
+			},
+			"revoke_group_grants": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "revoke the migrated privileges from the groups once the roles hold them",
+			},
+			"report": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema { Type: schema.TypeString },
+				Computed:    true,
+				Description: "statements run by the migration",
+			},
+		},
+	}
+}
+
+func resourceRedshiftGroupRoleMigrationCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+	groups := usersSetToList(d.Get("groups"))
+	rolePrefix := d.Get("role_prefix").(string)
+	revokeGroupGrants := d.Get("revoke_group_grants").(bool)
+
+	tx, txBeginErr := client.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftGroupRoleMigrationCreate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	var report []string
+	for _, group := range groups {
+		statements, migrateErr := redshiftMigrateGroupToRole(tx, group, rolePrefix+group, revokeGroupGrants)
+		if migrateErr != nil {
+			log.Println("error | resourceRedshiftGroupRoleMigrationCreate | migrateErr |", migrateErr)
+			tx.Rollback()
+			return migrateErr
+		}
+		report = append(report, statements...)
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftGroupRoleMigrationCreate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(rolePrefix + strings.Join(groups, ","))))
+	d.Set("report", report)
+	return nil
+}
+
+func resourceRedshiftGroupRoleMigrationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+	rolePrefix := d.Get("role_prefix").(string)
+
+	var roles []string
+	for _, group := range usersSetToList(d.Get("groups")) {
+		roles = append(roles, rolePrefix+group)
+	}
+
+	var roleCount int
+	selectQuery := fmt.Sprintf("SELECT COUNT(*) FROM svv_roles WHERE true %s", redshiftObjectFilter("role_name", roles))
+	if selectErr := client.QueryRow(selectQuery).Scan(&roleCount); selectErr != nil {
+		log.Println("error | resourceRedshiftGroupRoleMigrationRead | selectErr |", selectErr)
+		return selectErr
+	}
+
+	if roleCount < len(roles) {
+		log.Println("info | resourceRedshiftGroupRoleMigrationRead | migrated roles not found |", strings.Join(roles, ","))
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceRedshiftGroupRoleMigrationDelete(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+func redshiftMigrateGroupToRole(tx *sql.Tx, group string, role string, revokeGroupGrants bool) ([]string, error) {
+	var statements []string
+	exec := func(statement string) error {
+		if _, execErr := tx.Exec(statement); execErr != nil {
+			return fmt.Errorf("%s: %s", statement, execErr)
+		}
+		statements = append(statements, statement)
+		return nil
+	}
+
+	groupGrantee := redshiftGrantee {
+		granteeType: "group",
+		name:        group,
+	}
+	roleGrantee := redshiftGrantee {
+		granteeType: "role",
+		name:        role,
+	}
+
+	var groupId string
+	selectGroupQuery := fmt.Sprintf("SELECT grosysid FROM pg_group WHERE groname = '%s'", group)
+	if selectGroupErr := tx.QueryRow(selectGroupQuery).Scan(&groupId); selectGroupErr != nil {
+		if selectGroupErr == sql.ErrNoRows {
+			return nil, fmt.Errorf("Group %s does not exist", group)
+		}
+		return nil, selectGroupErr
+	}
+
+	var roleCount int
+	selectRoleQuery := fmt.Sprintf("SELECT COUNT(*) FROM svv_roles WHERE role_name = '%s'", role)
+	if selectRoleErr := tx.QueryRow(selectRoleQuery).Scan(&roleCount); selectRoleErr != nil {
+		return nil, selectRoleErr
+	}
+	if roleCount == 0 {
+		if createErr := exec(fmt.Sprintf("CREATE ROLE %s", role)); createErr != nil {
+			return nil, createErr
+		}
+	}
+
+	members, _, selectMembersErr := redshiftCollectByKey(tx, fmt.Sprintf(`
+		SELECT
+			u.usename,
+			''
+		FROM pg_group g
+			JOIN pg_user u ON u.usesysid = ANY(g.grolist)
+		WHERE g.grosysid = %s
+		ORDER BY u.usename
+	`, groupId))
+	if selectMembersErr != nil {
+		return nil, selectMembersErr
+	}
+	for _, member := range members {
+		if grantRoleErr := exec(fmt.Sprintf("GRANT ROLE %s TO %s", role, member)); grantRoleErr != nil {
+			return nil, grantRoleErr
+		}
+	}
+
+	schemas, schemaPrivileges, selectSchemasErr := redshiftCollectByKey(tx, fmt.Sprintf(`
+		SELECT
+			QUOTE_IDENT(namespace_name),
+			LOWER(privilege_type)
+		FROM svv_schema_privileges
+		WHERE identity_type = 'group'
+			AND identity_name = '%s'
+		ORDER BY namespace_name, privilege_type
+	`, group))
+	if selectSchemasErr != nil {
+		return nil, selectSchemasErr
+	}
+	for _, schema := range schemas {
+		target := redshiftGrantTarget {
+			objectType: "schema",
+			schema:     schema,
+		}
+		if grantErr := exec(redshiftGrantStatement(schemaPrivileges[schema], target, roleGrantee)); grantErr != nil {
+			return nil, grantErr
+		}
+	}
+
+	tables, tablePrivileges, selectTablesErr := redshiftCollectByKey(tx, fmt.Sprintf(`
+		SELECT
+			QUOTE_IDENT(namespace_name) + '|' + QUOTE_IDENT(relation_name),
+			LOWER(privilege_type)
+		FROM svv_relation_privileges
+		WHERE identity_type = 'group'
+			AND identity_name = '%s'
+		ORDER BY namespace_name, relation_name, privilege_type
+	`, group))
+	if selectTablesErr != nil {
+		return nil, selectTablesErr
+	}
+	for _, table := range tables {
+		if grantErr := exec(redshiftGrantStatement(tablePrivileges[table], redshiftMigrationTableTarget(table), roleGrantee)); grantErr != nil {
+			return nil, grantErr
+		}
+	}
+
+	defaults, defaultPrivileges, selectDefaultsErr := redshiftCollectByKey(tx, fmt.Sprintf(`
+		SELECT
+			QUOTE_IDENT(owner_name) + '|' + COALESCE(QUOTE_IDENT(schema_name), '') + '|' + object_type,
+			LOWER(privilege_type)
+		FROM svv_default_privileges
+		WHERE grantee_type = 'group'
+			AND grantee_name = '%s'
+		ORDER BY owner_name, schema_name, object_type, privilege_type
+	`, group))
+	if selectDefaultsErr != nil {
+		return nil, selectDefaultsErr
+	}
+	var defaultTargets [][]string
+	for _, key := range defaults {
+		parts := strings.SplitN(key, "|", 3)
+		objectType := redshiftDefaultAclObjectTypeBySvvType(parts[2])
+		if objectType == "" {
+			return nil, fmt.Errorf("Unsupported default privileges object type %s", parts[2])
+		}
+		owner, schema := parts[0], parts[1]
+		defaultTargets = append(defaultTargets, []string{owner, schema, objectType})
+
+		if grantErr := exec(redshiftDefaultPrivilegesStatement("GRANT", defaultPrivileges[key], owner, schema, objectType, roleGrantee)); grantErr != nil {
+			return nil, grantErr
+		}
+	}
+
+	if !revokeGroupGrants {
+		return statements, nil
+	}
+
+	for _, schema := range schemas {
+		target := redshiftGrantTarget {
+			objectType: "schema",
+			schema:     schema,
+		}
+		if revokeErr := exec(redshiftRevokeStatement(schemaPrivileges[schema], target, groupGrantee, false)); revokeErr != nil {
+			return nil, revokeErr
+		}
+	}
+
+	for _, table := range tables {
+		if revokeErr := exec(redshiftRevokeStatement(tablePrivileges[table], redshiftMigrationTableTarget(table), groupGrantee, false)); revokeErr != nil {
+			return nil, revokeErr
+		}
+	}
+
+	for i, key := range defaults {
+		owner, schema, objectType := defaultTargets[i][0], defaultTargets[i][1], defaultTargets[i][2]
+		if revokeErr := exec(redshiftDefaultPrivilegesStatement("REVOKE", defaultPrivileges[key], owner, schema, objectType, groupGrantee)); revokeErr != nil {
+			return nil, revokeErr
+		}
+	}
+
+	return statements, nil
+}
+
+// A table keyed as schema|table by the migration queries.
+func redshiftMigrationTableTarget(table string) redshiftGrantTarget {
+	parts := strings.SplitN(table, "|", 2)
+	return redshiftGrantTarget {
+		objectType: "table",
+		schema:     parts[0],
+		objects:    parts[1:],
+	}
+}
+
+// The default privileges object type, e.g. tables, that svv_default_privileges
+// reports as svvType, e.g. RELATION.
+func redshiftDefaultAclObjectTypeBySvvType(svvType string) string {
+	for objectType, defaultAclObjectType := range redshiftDefaultAclObjectTypes {
+		if defaultAclObjectType.svvType == svvType {
+			return objectType
+		}
+	}
+	return ""
+}
+
+// Collects (key, value) rows into the distinct keys, in query order, and the
+// values found for each key.
+func redshiftCollectByKey(tx *sql.Tx, query string) ([]string, map[string][]string, error) {
+	var keys []string
+	values := make(map[string][]string)
+
+	rows, selectErr := tx.Query(query)
+	if selectErr != nil {
+		return nil, nil, selectErr
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var key string
+		var value string
+		if selectRowErr := rows.Scan(&key, &value); selectRowErr != nil {
+			return nil, nil, selectRowErr
+		}
+		if _, ok := values[key]; !ok {
+			keys = append(keys, key)
+		}
+		values[key] = append(values[key], value)
+	}
+
+	return keys, values, rows.Err()
+}
@@ -0,0 +1,223 @@
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+
+func resourceRedshiftRole() *schema.Resource {
+	return &schema.Resource {
+		Create:        resourceRedshiftRoleCreate,
+		Read:          resourceRedshiftRoleRead,
+		Update:        resourceRedshiftRoleUpdate,
+		Delete:        resourceRedshiftRoleDelete,
+		CustomizeDiff: resourceRedshiftRoleCustomizeDiff,
+		Importer: &schema.ResourceImporter {
+			State: resourceRedshiftRoleImport,
+		},
+		Schema: map[string]*schema.Schema {
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"external_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "removing it recreates the role, as Redshift cannot clear an external ID",
+			},
+		},
+	}
+}
+
+func resourceRedshiftRoleCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+	name := d.Get("name")
+
+	tx, txBeginErr := client.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftRoleCreate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	createStatement := fmt.Sprintf("CREATE ROLE %s", name)
+	if externalId, ok := d.GetOk("external_id"); ok {
+		createStatement = fmt.Sprintf("%s EXTERNALID \"%s\"", createStatement, externalId)
+	}
+	if _, createErr := tx.Exec(createStatement); createErr != nil {
+		log.Println("error | resourceRedshiftRoleCreate | createErr |", createErr)
+		tx.Rollback()
+		return createErr
+	}
+
+	if owner, ok := d.GetOk("owner"); ok {
+		alterOwnerStatement := fmt.Sprintf("ALTER ROLE %s OWNER TO %s", name, owner)
+		if _, alterOwnerErr := tx.Exec(alterOwnerStatement); alterOwnerErr != nil {
+			log.Println("error | resourceRedshiftRoleCreate | alterOwnerErr |", alterOwnerErr)
+			tx.Rollback()
+			return alterOwnerErr
+		}
+	}
+
+	var id string
+	selectQuery := fmt.Sprintf("SELECT role_id FROM svv_roles WHERE role_name = '%s'", name)
+	selectErr := tx.QueryRow(selectQuery).Scan(&id)
+	if selectErr != nil {
+		log.Println("error | resourceRedshiftRoleCreate | selectErr |", selectErr)
+		tx.Rollback()
+		return selectErr
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftRoleCreate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	d.SetId(id)
+	return redshiftRoleRead(client, d)
+}
+
+func resourceRedshiftRoleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+	return redshiftRoleRead(client, d)
+}
+
+func resourceRedshiftRoleImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client).db
+
+	id, resolveErr := redshiftResolveImportId(client, "role", d.Id())
+	if resolveErr != nil {
+		log.Println("error | resourceRedshiftRoleImport | resolveErr |", resolveErr)
+		return nil, resolveErr
+	}
+
+	d.SetId(id)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRedshiftRoleUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+
+	tx, txBeginErr := client.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftRoleUpdate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	if d.HasChange("name") {
+		oldName, newName := d.GetChange("name")
+		alterNameStatement := fmt.Sprintf("ALTER ROLE %s RENAME TO %s", oldName, newName)
+		if _, alterNameErr := tx.Exec(alterNameStatement); alterNameErr != nil {
+			log.Println("error | resourceRedshiftRoleUpdate | alterNameErr |", alterNameErr)
+			tx.Rollback()
+			return alterNameErr
+		}
+	}
+
+	if d.HasChange("owner") {
+		name := d.Get("name")
+		owner := d.Get("owner")
+		alterOwnerStatement := fmt.Sprintf("ALTER ROLE %s OWNER TO %s", name, owner)
+		if _, alterOwnerErr := tx.Exec(alterOwnerStatement); alterOwnerErr != nil {
+			log.Println("error | resourceRedshiftRoleUpdate | alterOwnerErr |", alterOwnerErr)
+			tx.Rollback()
+			return alterOwnerErr
+		}
+	}
+
+	if d.HasChange("external_id") {
+		name := d.Get("name")
+		externalId := d.Get("external_id")
+		alterExternalIdStatement := fmt.Sprintf("ALTER ROLE %s EXTERNALID TO \"%s\"", name, externalId)
+		if _, alterExternalIdErr := tx.Exec(alterExternalIdStatement); alterExternalIdErr != nil {
+			log.Println("error | resourceRedshiftRoleUpdate | alterExternalIdErr |", alterExternalIdErr)
+			tx.Rollback()
+			return alterExternalIdErr
+		}
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftRoleUpdate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	return redshiftRoleRead(client, d)
+}
+
+func resourceRedshiftRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+	name := d.Get("name")
+
+	tx, txBeginErr := client.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftRoleDelete | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	dropStatement := fmt.Sprintf("DROP ROLE %s", name)
+	if _, dropErr := tx.Exec(dropStatement); dropErr != nil {
+		log.Println("error | resourceRedshiftRoleDelete | dropErr |", dropErr)
+		tx.Rollback()
+		return dropErr
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftRoleDelete | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	return nil
+}
+
+func resourceRedshiftRoleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// ALTER ROLE only sets EXTERNALID to another value
+	if d.HasChange("external_id") && d.NewValueKnown("external_id") && d.Get("external_id").(string) == "" {
+		return d.ForceNew("external_id")
+	}
+
+	return nil
+}
+
+func redshiftRoleRead(client *sql.DB, d *schema.ResourceData) error {
+	id := d.Id()
+
+	var name string
+	var owner string
+	var externalId string
+	selectQuery := fmt.Sprintf(`
+		SELECT
+			role_name,
+			role_owner,
+			COALESCE(external_id, '')
+		FROM svv_roles
+		WHERE role_id = %s
+	`, id)
+	selectErr := client.QueryRow(selectQuery).Scan(&name, &owner, &externalId)
+
+	if selectErr != nil {
+		log.Println("error | redshiftRoleRead | selectErr |", selectErr)
+		if selectErr == sql.ErrNoRows {
+			d.SetId("")
+			return nil
+		} else {
+			return selectErr
+		}
+	}
+
+	d.Set("name", name)
+	d.Set("owner", owner)
+	d.Set("external_id", externalId)
+
+	return nil
+}
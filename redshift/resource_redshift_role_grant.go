@@ -0,0 +1,239 @@
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+
+func resourceRedshiftRoleGrant() *schema.Resource {
+	return &schema.Resource {
+		Create:        resourceRedshiftRoleGrantCreate,
+		Read:          resourceRedshiftRoleGrantRead,
+		Delete:        resourceRedshiftRoleGrantDelete,
+		CustomizeDiff: resourceRedshiftRoleGrantCustomizeDiff,
+		Importer: &schema.ResourceImporter {
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema {
+			"role": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"user", "grantee_role"},
+			},
+			"grantee_role": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceRedshiftRoleGrantCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+	role := d.Get("role")
+	granteeType, grantee := redshiftRoleGrantGrantee(d)
+
+	tx, txBeginErr := client.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftRoleGrantCreate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	grantStatement := fmt.Sprintf("GRANT ROLE %s TO %s", role, grantee)
+	if granteeType == "role" {
+		grantStatement = fmt.Sprintf("GRANT ROLE %s TO ROLE %s", role, grantee)
+	}
+	if _, grantErr := tx.Exec(grantStatement); grantErr != nil {
+		log.Println("error | resourceRedshiftRoleGrantCreate | grantErr |", grantErr)
+		tx.Rollback()
+		return grantErr
+	}
+
+	var roleId string
+	selectRoleQuery := fmt.Sprintf("SELECT role_id FROM svv_roles WHERE role_name = '%s'", role)
+	selectRoleErr := tx.QueryRow(selectRoleQuery).Scan(&roleId)
+	if selectRoleErr != nil {
+		log.Println("error | resourceRedshiftRoleGrantCreate | selectRoleErr |", selectRoleErr)
+		tx.Rollback()
+		return selectRoleErr
+	}
+
+	var granteeId string
+	selectGranteeQuery := fmt.Sprintf("SELECT usesysid FROM pg_user WHERE usename = '%s'", grantee)
+	if granteeType == "role" {
+		selectGranteeQuery = fmt.Sprintf("SELECT role_id FROM svv_roles WHERE role_name = '%s'", grantee)
+	}
+	selectGranteeErr := tx.QueryRow(selectGranteeQuery).Scan(&granteeId)
+	if selectGranteeErr != nil {
+		log.Println("error | resourceRedshiftRoleGrantCreate | selectGranteeErr |", selectGranteeErr)
+		tx.Rollback()
+		return selectGranteeErr
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftRoleGrantCreate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	id := fmt.Sprintf("%s-%s-%s", roleId, granteeType, granteeId)
+	d.SetId(id)
+	return redshiftRoleGrantRead(client, d)
+}
+
+func resourceRedshiftRoleGrantRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+	return redshiftRoleGrantRead(client, d)
+}
+
+func resourceRedshiftRoleGrantDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+	role := d.Get("role")
+	granteeType, grantee := redshiftRoleGrantGrantee(d)
+
+	tx, txBeginErr := client.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftRoleGrantDelete | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	revokeStatement := fmt.Sprintf("REVOKE ROLE %s FROM %s", role, grantee)
+	if granteeType == "role" {
+		revokeStatement = fmt.Sprintf("REVOKE ROLE %s FROM ROLE %s", role, grantee)
+	}
+	if _, revokeErr := tx.Exec(revokeStatement); revokeErr != nil {
+		log.Println("error | resourceRedshiftRoleGrantDelete | revokeErr |", revokeErr)
+		tx.Rollback()
+		return revokeErr
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftRoleGrantDelete | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	return nil
+}
+
+// Granting a role to one of the roles it already (transitively) contains would
+// create a cycle, which Redshift only rejects at apply time.
+func resourceRedshiftRoleGrantCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("role") || !d.NewValueKnown("grantee_role") {
+		return nil
+	}
+
+	role := d.Get("role").(string)
+	granteeRole := d.Get("grantee_role").(string)
+	if granteeRole == "" {
+		return nil
+	}
+
+	if role == granteeRole {
+		return fmt.Errorf("Cannot grant role %s to itself", role)
+	}
+
+	client := meta.(*Client).db
+	rows, selectErr := client.Query("SELECT role_name, granted_role_name FROM svv_role_grants")
+	if selectErr != nil {
+		log.Println("error | resourceRedshiftRoleGrantCustomizeDiff | selectErr |", selectErr)
+		return selectErr
+	}
+
+	defer rows.Close()
+	grantedRoles := make(map[string][]string)
+	for rows.Next() {
+		var grantee string
+		var granted string
+		if selectRowErr := rows.Scan(&grantee, &granted); selectRowErr != nil {
+			log.Println("error | resourceRedshiftRoleGrantCustomizeDiff | selectRowErr |", selectRowErr)
+			return selectRowErr
+		}
+		grantedRoles[grantee] = append(grantedRoles[grantee], granted)
+	}
+
+	visited := make(map[string]bool)
+	pending := []string{role}
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+		if current == granteeRole {
+			return fmt.Errorf("Cannot grant role %s to role %s: %s is already granted to %s", role, granteeRole, granteeRole, role)
+		}
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		pending = append(pending, grantedRoles[current]...)
+	}
+
+	return nil
+}
+
+func redshiftRoleGrantGrantee(d *schema.ResourceData) (string, string) {
+	if granteeRole, ok := d.GetOk("grantee_role"); ok {
+		return "role", granteeRole.(string)
+	}
+	return "user", d.Get("user").(string)
+}
+
+func redshiftRoleGrantRead(client *sql.DB, d *schema.ResourceData) error {
+	id := d.Id()
+	parts := strings.SplitN(id, "-", 3)
+	if len(parts) != 3 {
+		return fmt.Errorf("Invalid role grant ID %s, expected role_id-user-usesysid or role_id-role-role_id", id)
+	}
+	roleId, granteeType, granteeId := parts[0], parts[1], parts[2]
+
+	var role string
+	var grantee string
+	selectQuery := fmt.Sprintf(`
+		SELECT
+			role_name,
+			user_name
+		FROM svv_user_grants
+		WHERE role_id = %s
+			AND user_id = %s
+	`, roleId, granteeId)
+	if granteeType == "role" {
+		selectQuery = fmt.Sprintf(`
+			SELECT
+				granted_role_name,
+				role_name
+			FROM svv_role_grants
+			WHERE granted_role_id = %s
+				AND role_id = %s
+		`, roleId, granteeId)
+	}
+	selectErr := client.QueryRow(selectQuery).Scan(&role, &grantee)
+
+	if selectErr != nil {
+		log.Println("error | redshiftRoleGrantRead | selectErr |", selectErr)
+		if selectErr == sql.ErrNoRows {
+			d.SetId("")
+			return nil
+		} else {
+			return selectErr
+		}
+	}
+
+	d.Set("role", role)
+	if granteeType == "role" {
+		d.Set("grantee_role", grantee)
+	} else {
+		d.Set("user", grantee)
+	}
+
+	return nil
+}
@@ -0,0 +1,280 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var redshiftSystemPermissions = []string{
+	"ACCESS CATALOG",
+	"ACCESS SYSTEM TABLE",
+	"ALTER DATASHARE",
+	"ALTER DEFAULT PRIVILEGES",
+	"ALTER TABLE",
+	"ALTER USER",
+	"ANALYZE",
+	"CANCEL",
+	"CREATE DATASHARE",
+	"CREATE LIBRARY",
+	"CREATE MODEL",
+	"CREATE OR REPLACE EXTERNAL FUNCTION",
+	"CREATE OR REPLACE FUNCTION",
+	"CREATE OR REPLACE PROCEDURE",
+	"CREATE OR REPLACE VIEW",
+	"CREATE ROLE",
+	"CREATE SCHEMA",
+	"CREATE TABLE",
+	"CREATE USER",
+	"DROP DATASHARE",
+	"DROP FUNCTION",
+	"DROP LIBRARY",
+	"DROP MODEL",
+	"DROP PROCEDURE",
+	"DROP ROLE",
+	"DROP SCHEMA",
+	"DROP TABLE",
+	"DROP USER",
+	"DROP VIEW",
+	"EXPLAIN MASKING",
+	"EXPLAIN RLS",
+	"IGNORE RLS",
+	"TRUNCATE TABLE",
+	"VACUUM",
+}
+
+
+func resourceRedshiftRoleSystemPermissions() *schema.Resource {
+	return &schema.Resource {
+		Create: resourceRedshiftRoleSystemPermissionsCreate,
+		Read:   resourceRedshiftRoleSystemPermissionsRead,
+		Update: resourceRedshiftRoleSystemPermissionsUpdate,
+		Delete: resourceRedshiftRoleSystemPermissionsDelete,
+		Importer: &schema.ResourceImporter {
+			State: resourceRedshiftRoleSystemPermissionsImport,
+		},
+		Schema: map[string]*schema.Schema {
+			"role": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"permissions": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema {
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(redshiftSystemPermissions, false),
+				},
+			},
+		},
+	}
+}
+
+func resourceRedshiftRoleSystemPermissionsCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+	role := d.Get("role")
+	permissions := usersSetToList(d.Get("permissions"))
+
+	tx, txBeginErr := client.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftRoleSystemPermissionsCreate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	var roleId string
+	selectRoleQuery := fmt.Sprintf("SELECT role_id FROM svv_roles WHERE role_name = '%s'", role)
+	selectRoleErr := tx.QueryRow(selectRoleQuery).Scan(&roleId)
+	if selectRoleErr != nil {
+		log.Println("error | resourceRedshiftRoleSystemPermissionsCreate | selectRoleErr |", selectRoleErr)
+		tx.Rollback()
+		return selectRoleErr
+	}
+
+	// the resource owns the role's system permissions, those held outside of it are revoked
+	heldPermissions, selectPermissionsErr := redshiftRoleSystemPermissionsHeld(tx, roleId)
+	if selectPermissionsErr != nil {
+		log.Println("error | resourceRedshiftRoleSystemPermissionsCreate | selectPermissionsErr |", selectPermissionsErr)
+		tx.Rollback()
+		return selectPermissionsErr
+	}
+
+	var revokedPermissions []string
+	for _, permission := range heldPermissions {
+		if !stringInList(permission, permissions) {
+			revokedPermissions = append(revokedPermissions, permission)
+		}
+	}
+
+	if len(revokedPermissions) > 0 {
+		revokeStatement := fmt.Sprintf("REVOKE %s FROM ROLE %s", strings.Join(revokedPermissions, ","), role)
+		if _, revokeErr := tx.Exec(revokeStatement); revokeErr != nil {
+			log.Println("error | resourceRedshiftRoleSystemPermissionsCreate | revokeErr |", revokeErr)
+			tx.Rollback()
+			return revokeErr
+		}
+	}
+
+	grantStatement := fmt.Sprintf("GRANT %s TO ROLE %s", strings.Join(permissions, ","), role)
+	if _, grantErr := tx.Exec(grantStatement); grantErr != nil {
+		log.Println("error | resourceRedshiftRoleSystemPermissionsCreate | grantErr |", grantErr)
+		tx.Rollback()
+		return grantErr
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftRoleSystemPermissionsCreate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	d.SetId(roleId)
+	return redshiftRoleSystemPermissionsRead(client, d)
+}
+
+func resourceRedshiftRoleSystemPermissionsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+	return redshiftRoleSystemPermissionsRead(client, d)
+}
+
+func resourceRedshiftRoleSystemPermissionsImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client).db
+
+	id, resolveErr := redshiftResolveImportId(client, "role", d.Id())
+	if resolveErr != nil {
+		log.Println("error | resourceRedshiftRoleSystemPermissionsImport | resolveErr |", resolveErr)
+		return nil, resolveErr
+	}
+
+	d.SetId(id)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRedshiftRoleSystemPermissionsUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+	role := d.Get("role")
+
+	tx, txBeginErr := client.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftRoleSystemPermissionsUpdate | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	if d.HasChange("permissions") {
+		oldPermissionsSet, newPermissionsSet := d.GetChange("permissions")
+		revokedPermissions := usersSetToList(oldPermissionsSet.(*schema.Set).Difference(newPermissionsSet.(*schema.Set)))
+		grantedPermissions := usersSetToList(newPermissionsSet.(*schema.Set).Difference(oldPermissionsSet.(*schema.Set)))
+
+		if len(revokedPermissions) > 0 {
+			revokeStatement := fmt.Sprintf("REVOKE %s FROM ROLE %s", strings.Join(revokedPermissions, ","), role)
+			if _, revokeErr := tx.Exec(revokeStatement); revokeErr != nil {
+				log.Println("error | resourceRedshiftRoleSystemPermissionsUpdate | revokeErr |", revokeErr)
+				tx.Rollback()
+				return revokeErr
+			}
+		}
+
+		if len(grantedPermissions) > 0 {
+			grantStatement := fmt.Sprintf("GRANT %s TO ROLE %s", strings.Join(grantedPermissions, ","), role)
+			if _, grantErr := tx.Exec(grantStatement); grantErr != nil {
+				log.Println("error | resourceRedshiftRoleSystemPermissionsUpdate | grantErr |", grantErr)
+				tx.Rollback()
+				return grantErr
+			}
+		}
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftRoleSystemPermissionsUpdate | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	return redshiftRoleSystemPermissionsRead(client, d)
+}
+
+func resourceRedshiftRoleSystemPermissionsDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).db
+	role := d.Get("role")
+	permissions := usersSetToList(d.Get("permissions"))
+
+	tx, txBeginErr := client.Begin()
+	if txBeginErr != nil {
+		log.Println("error | resourceRedshiftRoleSystemPermissionsDelete | txBeginErr |", txBeginErr)
+		return txBeginErr
+	}
+
+	if len(permissions) > 0 {
+		revokeStatement := fmt.Sprintf("REVOKE %s FROM ROLE %s", strings.Join(permissions, ","), role)
+		if _, revokeErr := tx.Exec(revokeStatement); revokeErr != nil {
+			log.Println("error | resourceRedshiftRoleSystemPermissionsDelete | revokeErr |", revokeErr)
+			tx.Rollback()
+			return revokeErr
+		}
+	}
+
+	if txCommitErr := tx.Commit(); txCommitErr != nil {
+		log.Println("error | resourceRedshiftRoleSystemPermissionsDelete | txCommitErr |", txCommitErr)
+		return txCommitErr
+	}
+
+	return nil
+}
+
+func redshiftRoleSystemPermissionsRead(client *sql.DB, d *schema.ResourceData) error {
+	id := d.Id()
+
+	var role string
+	selectRoleQuery := fmt.Sprintf("SELECT role_name FROM svv_roles WHERE role_id = %s", id)
+	selectRoleErr := client.QueryRow(selectRoleQuery).Scan(&role)
+
+	if selectRoleErr != nil {
+		log.Println("error | redshiftRoleSystemPermissionsRead | selectRoleErr |", selectRoleErr)
+		if selectRoleErr == sql.ErrNoRows {
+			d.SetId("")
+			return nil
+		} else {
+			return selectRoleErr
+		}
+	}
+
+	permissions, selectPermissionsErr := redshiftRoleSystemPermissionsHeld(client, id)
+	if selectPermissionsErr != nil {
+		log.Println("error | redshiftRoleSystemPermissionsRead | selectPermissionsErr |", selectPermissionsErr)
+		return selectPermissionsErr
+	}
+
+	d.Set("role", role)
+	d.Set("permissions", permissions)
+
+	return nil
+}
+
+func redshiftRoleSystemPermissionsHeld(client interface{ Query(string, ...interface{}) (*sql.Rows, error) }, roleId string) ([]string, error) {
+	var permissions = []string{}
+	selectPermissionsQuery := fmt.Sprintf(`
+		SELECT
+			UPPER(system_privilege)
+		FROM svv_system_privileges
+		WHERE identity_type = 'role'
+			AND identity_id = %s
+	`, roleId)
+	rows, selectPermissionsErr := client.Query(selectPermissionsQuery)
+	if selectPermissionsErr != nil {
+		return nil, selectPermissionsErr
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var permission string
+		if selectPermissionsRowErr := rows.Scan(&permission); selectPermissionsRowErr != nil {
+			return nil, selectPermissionsRowErr
+		}
+		permissions = append(permissions, permission)
+	}
+
+	return permissions, rows.Err()
+}
@@ -4,10 +4,16 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var redshiftSchemaQuotaRegexp = regexp.MustCompile(`(?i)^(\d+) *(MB|GB|TB)$|^UNLIMITED$`)
+
 
 func resourceRedshiftSchema() *schema.Resource {
 	return &schema.Resource {
@@ -16,7 +22,7 @@ func resourceRedshiftSchema() *schema.Resource {
 		Update: resourceRedshiftSchemaUpdate,
 		Delete: resourceRedshiftSchemaDelete,
 		Importer: &schema.ResourceImporter {
-			State: schema.ImportStatePassthrough,
+			State: resourceRedshiftSchemaImport,
 		},
 		Schema: map[string]*schema.Schema {
 			"name": {
@@ -28,6 +34,35 @@ func resourceRedshiftSchema() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"quota": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "UNLIMITED",
+				Description:      "storage quota, e.g. 500 MB, 10 GB, 1 TB or UNLIMITED",
+				ValidateFunc:     validation.StringMatch(redshiftSchemaQuotaRegexp, "must be a number followed by MB, GB or TB, or UNLIMITED"),
+				DiffSuppressFunc: redshiftSchemaQuotaDiffSuppress,
+			},
+			"destroy_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "restrict",
+				Description:  "restrict refuses to drop a schema that still has objects, cascade drops them too and retain leaves the schema in place",
+				ValidateFunc: validation.StringInSlice([]string{"restrict", "cascade", "retain"}, false),
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "manage a schema that already exists instead of failing on create",
+			},
+			"disk_usage_mb": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"quota_exceeded": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -42,10 +77,14 @@ func resourceRedshiftSchemaCreate(d *schema.ResourceData, meta interface{}) erro
 		return txBeginErr
 	}
 
-	createStatement := fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", name)
+	createStatement := fmt.Sprintf("CREATE SCHEMA %s", name)
+	if d.Get("adopt_existing").(bool) {
+		createStatement = fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", name)
+	}
 	if owner, ok := d.GetOk("owner"); ok {
 		createStatement = fmt.Sprintf("%s AUTHORIZATION %s", createStatement, owner)
 	}
+	createStatement = fmt.Sprintf("%s QUOTA %s", createStatement, d.Get("quota"))
 	if _, createErr := tx.Exec(createStatement); createErr != nil {
 		log.Println("error | resourceRedshiftSchemaCreate | createErr |", createErr)
 		tx.Rollback()
@@ -75,6 +114,21 @@ func resourceRedshiftSchemaRead(d *schema.ResourceData, meta interface{}) error
 	return redshiftSchemaRead(client, d)
 }
 
+func resourceRedshiftSchemaImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client).db
+
+	id, resolveErr := redshiftResolveImportId(client, "schema", d.Id())
+	if resolveErr != nil {
+		log.Println("error | resourceRedshiftSchemaImport | resolveErr |", resolveErr)
+		return nil, resolveErr
+	}
+
+	d.SetId(id)
+	d.Set("destroy_policy", "restrict")
+	d.Set("adopt_existing", false)
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceRedshiftSchemaUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Client).db
 
@@ -105,6 +159,17 @@ func resourceRedshiftSchemaUpdate(d *schema.ResourceData, meta interface{}) erro
 		}
 	}
 
+	if d.HasChange("quota") {
+		name := d.Get("name")
+		quota := d.Get("quota")
+		alterQuotaStatement := fmt.Sprintf("ALTER SCHEMA %s QUOTA %s", name, quota)
+		if _, alterQuotaErr := tx.Exec(alterQuotaStatement); alterQuotaErr != nil {
+			log.Println("error | resourceRedshiftSchemaUpdate | alterQuotaErr |", alterQuotaErr)
+			tx.Rollback()
+			return alterQuotaErr
+		}
+	}
+
 	if txCommitErr := tx.Commit(); txCommitErr != nil {
 		log.Println("error | resourceRedshiftSchemaUpdate | txCommitErr |", txCommitErr)
 		return txCommitErr
@@ -116,6 +181,12 @@ func resourceRedshiftSchemaUpdate(d *schema.ResourceData, meta interface{}) erro
 func resourceRedshiftSchemaDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Client).db
 	name := d.Get("name")
+	destroyPolicy := d.Get("destroy_policy").(string)
+
+	if destroyPolicy == "retain" {
+		log.Println("info | resourceRedshiftSchemaDelete | retaining schema", name)
+		return nil
+	}
 
 	tx, txBeginErr := client.Begin()
 	if txBeginErr != nil {
@@ -123,7 +194,7 @@ func resourceRedshiftSchemaDelete(d *schema.ResourceData, meta interface{}) erro
 		return txBeginErr
 	}
 
-	dropStatement := fmt.Sprintf("DROP SCHEMA %s", name)
+	dropStatement := fmt.Sprintf("DROP SCHEMA %s %s", name, strings.ToUpper(destroyPolicy))
 	if _, dropErr := tx.Exec(dropStatement); dropErr != nil {
 		log.Println("error | resourceRedshiftSchemaDelete | dropErr |", dropErr)
 		tx.Rollback()
@@ -141,17 +212,8 @@ func resourceRedshiftSchemaDelete(d *schema.ResourceData, meta interface{}) erro
 func redshiftSchemaRead(client *sql.DB, d *schema.ResourceData) error {
 	id := d.Id()
 
-	var name string
-	var owner string
-	selectQuery := fmt.Sprintf(`
-		SELECT
-			nspname,
-			usename
-		FROM pg_namespace n
-			JOIN pg_user u ON u.usesysid = n.nspowner
-		WHERE n.oid =  %s
-	`, id)
-	selectErr := client.QueryRow(selectQuery).Scan(&name, &owner)
+	selectQuery := fmt.Sprintf("%s WHERE n.oid = %s", redshiftSchemaSelectQuery, id)
+	schemaInfo, selectErr := redshiftSchemaScan(client.QueryRow(selectQuery))
 
 	if selectErr != nil {
 		log.Println("error | redshiftSchemaRead | selectErr |", selectErr)
@@ -163,8 +225,78 @@ func redshiftSchemaRead(client *sql.DB, d *schema.ResourceData) error {
 		}
 	}
 
-	d.Set("name", name)
-	d.Set("owner", owner)
+	d.Set("name", schemaInfo.name)
+	d.Set("owner", schemaInfo.owner)
+	if schemaInfo.quotaVisible {
+		d.Set("quota", schemaInfo.quota())
+		d.Set("disk_usage_mb", schemaInfo.diskUsage)
+		d.Set("quota_exceeded", schemaInfo.quotaExceeded())
+	} else {
+		log.Println("info | redshiftSchemaRead | quota of", schemaInfo.name, "is only visible to its owner and superusers, keeping", d.Get("quota"))
+	}
 
 	return nil
 }
+
+const redshiftSchemaSelectQuery = `
+		SELECT
+			n.oid,
+			n.nspname,
+			u.usename,
+			COALESCE(q.quota, 0),
+			COALESCE(q.disk_usage, 0),
+			u.usename = current_user OR EXISTS (SELECT 1 FROM pg_user s WHERE s.usename = current_user AND s.usesuper)
+		FROM pg_namespace n
+			JOIN pg_user u ON u.usesysid = n.nspowner
+			LEFT JOIN svv_schema_quota_state q ON q.schema_id = n.oid
+	`
+
+// svv_schema_quota_state only shows other users the schemas they own, so for
+// anyone but the owner or a superuser a missing row says nothing about the quota.
+type redshiftSchemaInfo struct {
+	id           string
+	name         string
+	owner        string
+	quotaMB      int64
+	diskUsage    int64
+	quotaVisible bool
+}
+
+func (s redshiftSchemaInfo) quota() string {
+	if s.quotaMB <= 0 {
+		return "UNLIMITED"
+	}
+	return fmt.Sprintf("%d MB", s.quotaMB)
+}
+
+func (s redshiftSchemaInfo) quotaExceeded() bool {
+	return s.quotaMB > 0 && s.diskUsage > s.quotaMB
+}
+
+func redshiftSchemaScan(row interface{ Scan(...interface{}) error }) (redshiftSchemaInfo, error) {
+	var schemaInfo redshiftSchemaInfo
+	scanErr := row.Scan(&schemaInfo.id, &schemaInfo.name, &schemaInfo.owner, &schemaInfo.quotaMB, &schemaInfo.diskUsage, &schemaInfo.quotaVisible)
+	return schemaInfo, scanErr
+}
+
+// Quotas are read back in MB, so "1 GB" in the configuration and "1024 MB" in
+// the state are the same quota.
+func redshiftSchemaQuotaDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	return redshiftSchemaQuotaMB(old) == redshiftSchemaQuotaMB(new)
+}
+
+func redshiftSchemaQuotaMB(quota string) int64 {
+	match := redshiftSchemaQuotaRegexp.FindStringSubmatch(strings.TrimSpace(quota))
+	if match == nil || match[1] == "" {
+		return 0
+	}
+
+	size, _ := strconv.ParseInt(match[1], 10, 64)
+	switch strings.ToUpper(match[2]) {
+	case "GB":
+		return size * 1024
+	case "TB":
+		return size * 1024 * 1024
+	}
+	return size
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -16,7 +17,7 @@ func resourceRedshiftUser() *schema.Resource {
 		Update: resourceRedshiftUserUpdate,
 		Delete: resourceRedshiftUserDelete,
 		Importer: &schema.ResourceImporter {
-			State: schema.ImportStatePassthrough,
+			State: resourceRedshiftUserImport,
 		},
 		Schema: map[string]*schema.Schema {
 			"name": {
@@ -28,6 +29,32 @@ func resourceRedshiftUser() *schema.Resource {
 				Required:  true,
 				Sensitive: true,
 			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "false locks the user out, enabling a user disabled before it was imported resets its connection limit and expiry to unlimited",
+			},
+			"restore_connection_limit": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "connection limit restored when the user is enabled again",
+			},
+			"restore_valid_until": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "password expiry restored when the user is enabled again",
+			},
+			"terminate_sessions_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"terminate_sessions_on_password_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
@@ -50,6 +77,14 @@ func resourceRedshiftUserCreate(d *schema.ResourceData, meta interface{}) error
 		return createErr
 	}
 
+	if !d.Get("enabled").(bool) {
+		if disableErr := redshiftUserDisable(tx, d); disableErr != nil {
+			log.Println("error | resourceRedshiftUserCreate | disableErr |", disableErr)
+			tx.Rollback()
+			return disableErr
+		}
+	}
+
 	var id string
 	selectQuery := fmt.Sprintf("SELECT usesysid FROM pg_user WHERE usename = '%s'", name)
 	selectErr := tx.QueryRow(selectQuery).Scan(&id)
@@ -73,6 +108,21 @@ func resourceRedshiftUserRead(d *schema.ResourceData, meta interface{}) error {
 	return redshiftUserRead(client, d)
 }
 
+func resourceRedshiftUserImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client).db
+
+	id, resolveErr := redshiftResolveImportId(client, "user", d.Id())
+	if resolveErr != nil {
+		log.Println("error | resourceRedshiftUserImport | resolveErr |", resolveErr)
+		return nil, resolveErr
+	}
+
+	d.SetId(id)
+	d.Set("terminate_sessions_on_destroy", false)
+	d.Set("terminate_sessions_on_password_change", false)
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceRedshiftUserUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Client).db
 
@@ -95,6 +145,7 @@ func resourceRedshiftUserUpdate(d *schema.ResourceData, meta interface{}) error
 	if d.HasChange("password") {
 		name := d.Get("name")
 		password := d.Get("password")
+
 		alterPasswordStatement := fmt.Sprintf("ALTER USER %s PASSWORD '%s'", name, password)
 		if _, alterPasswordrErr := tx.Exec(alterPasswordStatement); alterPasswordrErr != nil {
 			log.Println("error | resourceRedshiftUserUpdate | alterPasswordrErr |", alterPasswordrErr)
@@ -103,11 +154,36 @@ func resourceRedshiftUserUpdate(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
+	if d.HasChange("enabled") {
+		if d.Get("enabled").(bool) {
+			if enableErr := redshiftUserEnable(tx, d); enableErr != nil {
+				log.Println("error | resourceRedshiftUserUpdate | enableErr |", enableErr)
+				tx.Rollback()
+				return enableErr
+			}
+		} else {
+			if disableErr := redshiftUserDisable(tx, d); disableErr != nil {
+				log.Println("error | resourceRedshiftUserUpdate | disableErr |", disableErr)
+				tx.Rollback()
+				return disableErr
+			}
+		}
+	}
+
 	if txCommitErr := tx.Commit(); txCommitErr != nil {
 		log.Println("error | resourceRedshiftUserUpdate | txCommitErr |", txCommitErr)
 		return txCommitErr
 	}
 
+	// only once the new password is committed, or a client could reconnect with the old one
+	// a disabled user is locked out of the sessions it already holds as well
+	if (d.HasChange("password") && d.Get("terminate_sessions_on_password_change").(bool)) || (d.HasChange("enabled") && !d.Get("enabled").(bool)) {
+		if terminateErr := redshiftUserTerminateSessions(client, d.Get("name").(string)); terminateErr != nil {
+			log.Println("error | resourceRedshiftUserUpdate | terminateErr |", terminateErr)
+			return terminateErr
+		}
+	}
+
 	return redshiftUserRead(client, d)
 }
 
@@ -121,6 +197,14 @@ func resourceRedshiftUserDelete(d *schema.ResourceData, meta interface{}) error
 		return txBeginErr
 	}
 
+	if d.Get("terminate_sessions_on_destroy").(bool) {
+		if terminateErr := redshiftUserTerminateSessions(tx, name.(string)); terminateErr != nil {
+			log.Println("error | resourceRedshiftUserDelete | terminateErr |", terminateErr)
+			tx.Rollback()
+			return terminateErr
+		}
+	}
+
 	dropStatement := fmt.Sprintf("DROP USER %s", name)
 	if _, dropErr := tx.Exec(dropStatement); dropErr != nil {
 		log.Println("error | resourceRedshiftUserDelete | dropErr |", dropErr)
@@ -139,9 +223,8 @@ func resourceRedshiftUserDelete(d *schema.ResourceData, meta interface{}) error
 func redshiftUserRead(client *sql.DB, d *schema.ResourceData) error {
 	id := d.Id()
 
-	var name string
-	selectQuery := fmt.Sprintf("SELECT usename FROM pg_user WHERE usesysid = %s", id)
-	selectErr := client.QueryRow(selectQuery).Scan(&name)
+	selectQuery := fmt.Sprintf("%s WHERE u.usesysid = %s", redshiftUserSelectQuery, id)
+	user, selectErr := redshiftUserScan(client.QueryRow(selectQuery))
 
 	if selectErr != nil {
 		log.Println("error | redshiftUserRead | selectErr", selectErr)
@@ -153,7 +236,139 @@ func redshiftUserRead(client *sql.DB, d *schema.ResourceData) error {
 		}
 	}
 
-	d.Set("name", name)
+	d.Set("name", user.name)
+	d.Set("enabled", user.enabled())
+
+	return nil
+}
+
+// pg_user_info is only visible to superusers, so for anyone else the connection
+// limit reads as UNLIMITED rather than the user going missing.
+const redshiftUserSelectQuery = `
+		SELECT
+			u.usesysid,
+			u.usename,
+			u.usesuper,
+			u.usecreatedb,
+			COALESCE(TRIM(i.useconnlimit), 'UNLIMITED'),
+			COALESCE(TO_CHAR(u.valuntil, 'YYYY-MM-DD HH24:MI:SS'), 'infinity')
+		FROM pg_user u
+			LEFT JOIN pg_user_info i ON i.usesysid = u.usesysid
+	`
+
+type redshiftUserInfo struct {
+	id              string
+	name            string
+	superuser       bool
+	createdb        bool
+	connectionLimit string
+	validUntil      string
+}
+
+// Only the lock redshiftUserDisable applies counts as disabled, a password that
+// simply expired leaves the user enabled.
+func (u redshiftUserInfo) enabled() bool {
+	return !(u.connectionLimit == "0" && strings.HasPrefix(u.validUntil, "1970-01-01"))
+}
+
+func redshiftUserScan(row interface{ Scan(...interface{}) error }) (redshiftUserInfo, error) {
+	var user redshiftUserInfo
+	scanErr := row.Scan(&user.id, &user.name, &user.superuser, &user.createdb, &user.connectionLimit, &user.validUntil)
+	return user, scanErr
+}
+
+// Disabling a user keeps its ownerships and grants intact and blocks new
+// logins, the update terminates its open sessions once this is committed. The
+// previous connection limit and password expiry are kept in state so they can
+// be put back by redshiftUserEnable.
+func redshiftUserDisable(tx *sql.Tx, d *schema.ResourceData) error {
+	name := d.Get("name")
+
+	selectQuery := fmt.Sprintf("%s WHERE u.usename = '%s'", redshiftUserSelectQuery, name)
+	user, selectErr := redshiftUserScan(tx.QueryRow(selectQuery))
+	if selectErr != nil {
+		log.Println("error | redshiftUserDisable | selectErr |", selectErr)
+		return selectErr
+	}
+
+	connectionLimit, validUntil := user.connectionLimit, user.validUntil
+
+	// a user that is already locked out has nothing worth restoring
+	if connectionLimit == "0" {
+		connectionLimit = "UNLIMITED"
+		validUntil = "infinity"
+	}
+
+	disableStatement := fmt.Sprintf("ALTER USER %s CONNECTION LIMIT 0 VALID UNTIL '1970-01-01'", name)
+	if _, disableErr := tx.Exec(disableStatement); disableErr != nil {
+		log.Println("error | redshiftUserDisable | disableErr |", disableErr)
+		return disableErr
+	}
+
+	d.Set("restore_connection_limit", connectionLimit)
+	d.Set("restore_valid_until", validUntil)
+	return nil
+}
+
+func redshiftUserEnable(tx *sql.Tx, d *schema.ResourceData) error {
+	name := d.Get("name")
+
+	connectionLimit := d.Get("restore_connection_limit").(string)
+	if connectionLimit == "" || connectionLimit == "0" {
+		connectionLimit = "UNLIMITED"
+	}
+	validUntil := d.Get("restore_valid_until").(string)
+	if validUntil == "" {
+		validUntil = "infinity"
+	}
+
+	enableStatement := fmt.Sprintf("ALTER USER %s CONNECTION LIMIT %s VALID UNTIL '%s'", name, connectionLimit, validUntil)
+	if _, enableErr := tx.Exec(enableStatement); enableErr != nil {
+		log.Println("error | redshiftUserEnable | enableErr |", enableErr)
+		return enableErr
+	}
+
+	d.Set("restore_connection_limit", "")
+	d.Set("restore_valid_until", "")
+	return nil
+}
+
+func redshiftUserTerminateSessions(client interface {
+	Query(string, ...interface{}) (*sql.Rows, error)
+	Exec(string, ...interface{}) (sql.Result, error)
+}, name string) error {
+	var processes []int64
+	selectQuery := fmt.Sprintf(`
+		SELECT
+			process
+		FROM stv_sessions
+		WHERE TRIM(user_name) = '%s'
+			AND process <> pg_backend_pid()
+	`, name)
+	rows, selectErr := client.Query(selectQuery)
+	if selectErr != nil {
+		log.Println("error | redshiftUserTerminateSessions | selectErr |", selectErr)
+		return selectErr
+	}
+
+	for rows.Next() {
+		var process int64
+		if selectRowErr := rows.Scan(&process); selectRowErr != nil {
+			log.Println("error | redshiftUserTerminateSessions | selectRowErr |", selectRowErr)
+			rows.Close()
+			return selectRowErr
+		}
+		processes = append(processes, process)
+	}
+	rows.Close()
+
+	for _, process := range processes {
+		terminateStatement := fmt.Sprintf("SELECT pg_terminate_backend(%d)", process)
+		if _, terminateErr := client.Exec(terminateStatement); terminateErr != nil {
+			log.Println("error | redshiftUserTerminateSessions | terminateErr |", terminateErr)
+			return terminateErr
+		}
+	}
 
 	return nil
 }